@@ -20,24 +20,55 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
+	"maps"
 	"os"
-	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/hashicorp/go-version"
 )
 
-var client = &http.Client{}
+// changeEntry is a single release note entry of a component.
+type changeEntry struct {
+	// Version is the release the entry was published in, without the 'v' prefix.
+	Version string `json:"version"`
+	Text    string `json:"text"`
+	// Audience is the changelog the entry is listed in, user (end user changelog) or api (API changelog).
+	Audience string `json:"audience"`
+	// AlsoIn lists the later versions that repeated the entry, e.g. patch releases and backports.
+	AlsoIn []string `json:"also_in,omitempty"`
+	// RevertedIn is the version that reverted the entry within the analyzed range, the net effect is no change.
+	RevertedIn string `json:"reverted_in,omitempty"`
+}
 
-type categoryToChangesMap = map[string][]string
+type categoryToChangesMap = map[string][]changeEntry
 
 const breakingChanges = "breaking_changes"
 const deprecations = "deprecations"
 const enhancements = "enhancements"
 
+// reverted holds the changes reverted within the analyzed range, they are not part of the other categories.
+const reverted = "reverted"
+
+// allCategories lists the categories in the order they are reported.
+var allCategories = []string{breakingChanges, deprecations, enhancements}
+
+// reportedCategories lists all categories of a report, including reverted changes.
+var reportedCategories = []string{breakingChanges, deprecations, enhancements, reverted}
+
+const audienceUser = "user"
+const audienceAPI = "api"
+const audienceAll = "all"
+
+const formatMarkdown = "markdown"
+const formatJSON = "json"
+
+const groupByComponent = "component"
+const groupByVersion = "version"
+
 // parseVersion parses a version string by stripping the 'v' prefix and creating a version object.
 func parseVersion(verStr string) (*version.Version, error) {
 	verStr = strings.TrimPrefix(verStr, "v")
@@ -60,24 +91,83 @@ func parseLinkHeader(header string) map[string]string {
 	return links
 }
 
-// getVersionsBetween retrieves all released versions between oldVersion and newVersion from GitHub.
-func getVersionsBetween(oldVersion, newVersion string, opentelemetryRepo string) ([]*version.Version, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/open-telemetry/%s/releases?per_page=100", opentelemetryRepo)
+// releaseFilter selects which releases of a repository form the analyzed version series.
+type releaseFilter struct {
+	// IncludePrereleases keeps releases marked as pre-release on GitHub, such as release candidates.
+	IncludePrereleases bool
+	// TagPrefix selects a tagged sub-module stream, e.g. cmd/builder for cmd/builder/v0.106.1 tags.
+	// When empty, only root module tags without a '/' are analyzed.
+	TagPrefix string
+}
+
+// fullTag returns the tag including the sub-module prefix, e.g. cmd/builder/v0.106.1 for v0.106.1.
+func (f releaseFilter) fullTag(tag string) string {
+	if f.TagPrefix == "" || strings.HasPrefix(tag, f.TagPrefix+"/") {
+		return tag
+	}
+	return f.TagPrefix + "/" + tag
+}
+
+// seriesVersion returns the version part of a tag that belongs to the series, or false for tags of other series.
+func (f releaseFilter) seriesVersion(tag string) (string, bool) {
+	if f.TagPrefix == "" {
+		// collector has some release versions like, cmd/builder/v0.106.1, those belong to other series.
+		return tag, !strings.Contains(tag, "/")
+	}
+	ver, ok := strings.CutPrefix(tag, f.TagPrefix+"/")
+	return ver, ok && !strings.Contains(ver, "/")
+}
+
+// release is a single GitHub release of the analyzed version series.
+type release struct {
+	// Tag is the full git tag, including the sub-module prefix.
+	Tag         string           `json:"tag"`
+	Version     *version.Version `json:"version"`
+	PublishedAt time.Time        `json:"published_at,omitzero"`
+	// PreviousTag is the tag of the preceding release of the series, empty for the first one.
+	PreviousTag string `json:"previous_tag,omitempty"`
+	// sections are the changes of a release read from a markdown changelog, nil for GitHub releases.
+	sections map[string][]changeEntry
+}
+
+// githubRelease is a release as listed by the GitHub API or by a markdown changelog.
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	sections    map[string][]changeEntry
+}
+
+// getVersionsBetween retrieves all released versions between oldVersion and newVersion, both included, from GitHub or from the changelog of the repository.
+func getVersionsBetween(oldVersion, newVersion string, repo githubRepo, filter releaseFilter) ([]release, error) {
+	resolved, err := resolveReleases(versionRange{Old: oldVersion, New: newVersion}, repo, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.Releases, nil
+}
+
+// listReleases lists all releases of the repository, from GitHub or from its changelog.
+func listReleases(repo githubRepo) ([]githubRelease, error) {
+	if repo.isChangelog() {
+		return listChangelogReleases(repo)
+	}
+	return listGitHubReleases(repo)
+}
 
-	var allReleases []string
+// listGitHubReleases lists all releases of the repository with the GitHub API.
+func listGitHubReleases(repo githubRepo) ([]githubRelease, error) {
+	url := repo.releasesURL()
+	var allReleases []githubRelease
 	for url != "" {
 		response, err := getResponse(url)
 		if err != nil {
 			return nil, fmt.Errorf("get request failed for url %s: %v", url, err)
 		}
-		defer response.Body.Close()
-
-		var releases []struct {
-			TagName    string `json:"tag_name"`
-			Prerelease bool   `json:"prerelease"`
-		}
-		// Read the body into bytes for logging and decoding
+		var releases []githubRelease
+		// Read the body into bytes for logging and decoding, closing it before the next page is requested
 		bodyBytes, err := io.ReadAll(response.Body)
+		response.Body.Close()
 		if err != nil {
 			return nil, fmt.Errorf("failed to read response body: %v", err)
 		}
@@ -85,70 +175,63 @@ func getVersionsBetween(oldVersion, newVersion string, opentelemetryRepo string)
 		if err := json.Unmarshal(bodyBytes, &releases); err != nil {
 			return nil, fmt.Errorf("failed to decode releases: %v", err)
 		}
+		allReleases = append(allReleases, releases...)
 
-		for _, rel := range releases {
-			if !rel.Prerelease {
-				allReleases = append(allReleases, rel.TagName)
-			}
-		}
-
-		linkHeader := response.Header.Get("Link")
-		if linkHeader != "" {
-			links := parseLinkHeader(linkHeader)
-			url = links["next"]
-			break
-		}
+		// The last page has no next link
+		url = parseLinkHeader(response.Header.Get("Link"))["next"]
 	}
+	return allReleases, nil
+}
 
-	// Parse the boundary versions
-	oldVer, err := parseVersion(oldVersion)
+// listChangelogReleases lists the releases of the markdown changelog of the repository.
+func listChangelogReleases(repo githubRepo) ([]githubRelease, error) {
+	changelog, err := fetchChangelog(repo)
 	if err != nil {
-		return nil, fmt.Errorf("invalid old version %s: %v", oldVersion, err)
+		return nil, fmt.Errorf("failed to read changelog %s: %v", repo, err)
 	}
-	newVer, err := parseVersion(newVersion)
-	if err != nil {
-		return nil, fmt.Errorf("invalid new version %s: %v", newVersion, err)
+	releases := make([]githubRelease, 0, len(changelog))
+	for _, rel := range changelog {
+		releases = append(releases, githubRelease{TagName: rel.Tag, PublishedAt: rel.PublishedAt, sections: rel.Sections})
 	}
+	return releases, nil
+}
 
-	var filtered []*version.Version
-	for _, tag := range allReleases {
-		// collector has some release versions like, cmd/builder/v0.106.1, we do not care about those.
-		if strings.Contains(tag, "/") {
+// releaseSeries returns the releases of the series selected by the filter in ascending order, linked to their previous release.
+func releaseSeries(allReleases []githubRelease, filter releaseFilter) []release {
+	var series []release
+	for _, rel := range allReleases {
+		if rel.Prerelease && !filter.IncludePrereleases {
 			continue
 		}
-		ver, err := version.NewVersion(tag)
+		verStr, ok := filter.seriesVersion(rel.TagName)
+		if !ok {
+			continue
+		}
+		ver, err := version.NewVersion(verStr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse version %s: %v", tag, err)
+			fmt.Fprintf(os.Stderr, "Warning: skipping release %s with unparseable version: %v\n", rel.TagName, err)
+			continue
 		}
-		if ver.GreaterThanOrEqual(oldVer) && ver.LessThanOrEqual(newVer) {
-			filtered = append(filtered, ver)
+		// Release candidates can be tagged without being marked as pre-release on GitHub
+		if ver.Prerelease() != "" && !filter.IncludePrereleases {
+			continue
 		}
+		series = append(series, release{Tag: rel.TagName, Version: ver, PublishedAt: rel.PublishedAt, sections: rel.sections})
 	}
 
 	// Sort versions in ascending order
-	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].Compare(filtered[j]) < 0
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Version.Compare(series[j].Version) < 0
 	})
-
-	return filtered, nil
-}
-
-// getResponse calls http.Get for given url and returns the response
-func getResponse(url string) (*http.Response, error) {
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to GET response for url:  %s: %v", url, err)
+	for i := 1; i < len(series); i++ {
+		series[i].PreviousTag = series[i-1].Tag
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GET request status is %d for url %s", resp.StatusCode, url)
-	}
-	return resp, err
+	return series
 }
 
-// fetchReleaseNotes retrieves the HTML content of release notes for a specific version.
-func fetchReleaseNotes(version string, opentelemetryRepo string) (string, error) {
-	url := fmt.Sprintf("https://github.com/open-telemetry/%s/releases/tag/v%s", opentelemetryRepo, version)
+// fetchReleaseNotes retrieves the HTML content of release notes for a specific release tag.
+func fetchReleaseNotes(tag string, repo githubRepo) (string, error) {
+	url := repo.releaseURL(tag)
 	response, err := getResponse(url)
 	if err != nil {
 		return "", err
@@ -162,44 +245,51 @@ func fetchReleaseNotes(version string, opentelemetryRepo string) (string, error)
 }
 
 // extractReleaseSections extracts specified sections (e.g., Breaking changes, Deprecations, Enhancements) from HTML content.
-func extractReleaseSections(htmlContent string) (map[string][]string, error) {
+// Every entry is tagged with the audience of the changelog it is listed in, entries before any changelog header are for end users.
+func extractReleaseSections(htmlContent string) (map[string][]changeEntry, error) {
 	// Define sections to extract and their corresponding categories
 	sectionPhrases := map[string]string{
 		"Breaking changes": breakingChanges,
 		"Deprecations":     deprecations,
 		"Enhancements":     enhancements,
+		"Bug fixes":        skippedSection,
 	}
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
 	if err != nil {
-		fmt.Printf("failed to parse HTML: %v", err)
 		return nil, fmt.Errorf("failed to parse HTML: %v", err)
 	}
 
-	sectionMap := make(map[string][]string)
+	sectionMap := make(map[string][]changeEntry)
+	audience := audienceUser
 	doc.Find("h1, h2, h3").Each(func(i int, s *goquery.Selection) {
 		text := strings.TrimSpace(s.Text())
 
-		// Skip the changelog type headers
-		if strings.Contains(text, "End user changelog") || strings.Contains(text, "API changelog") {
+		// The changelog type headers select the audience of the following sections
+		lower := strings.ToLower(text)
+		if strings.Contains(lower, "end user changelog") {
+			audience = audienceUser
+			return // Continue to next element
+		}
+		if strings.Contains(lower, "api changelog") {
+			audience = audienceAPI
 			return // Continue to next element
 		}
 
-		// Process section headers regardless of which changelog they belong to
+		// Process section headers of both changelogs
 		for phrase, category := range sectionPhrases {
 			if strings.Contains(text, phrase) {
-				var changes []string
+				var changes []changeEntry
 				node := s.Next() // Get the next sibling after <h3>
 				if node.Length() > 0 && node.Is("ul") {
 					for child := node.Children().First(); child.Length() > 0; child = child.Next() {
 						if !child.Is("li") {
 							continue
 						}
-						changeText := strings.TrimSpace(child.Text())
+						changeText := listItemMarkdown(child)
 						if changeText == "" {
 							continue
 						}
-						reformated := strings.ReplaceAll(changeText, "\n", "\n             ")
-						changes = append(changes, reformated)
+						changes = append(changes, changeEntry{Text: changeText, Audience: audience})
 					}
 				}
 				sectionMap[category] = append(sectionMap[category], changes...)
@@ -209,156 +299,488 @@ func extractReleaseSections(htmlContent string) (map[string][]string, error) {
 	return sectionMap, nil
 }
 
-// getComponentChanges retrieves breaking changes, deprecations, and enhancements for specified components across versions.
-func getComponentChanges(versionOld, versionNew string, componentsOfInterest []string, opentelemetryRepo string) (map[string]categoryToChangesMap, error) {
-	versions, err := getVersionsBetween(versionOld, versionNew, opentelemetryRepo)
+// analysisOptions controls which releases and changes are analyzed and how the result is rendered.
+type analysisOptions struct {
+	Filter releaseFilter
+	// Range selects the analyzed releases of the series.
+	Range versionRange
+	// Aliases maps a component to other names it is referred to by in release notes, e.g. receiver/prometheus.
+	Aliases map[string][]string
+	// Categories limits the analysis to the given categories, all categories are analyzed when empty.
+	Categories []string
+	// Audience limits the analysis to the entries of one changelog, user or api. All entries are analyzed when empty or all.
+	Audience string
+	// Format is the output format, markdown (default) or json.
+	Format string
+	// GroupBy selects the sections of the markdown report, component (default) or version.
+	GroupBy string
+	Encode  bool
+	// Clone is a local clone of the repository. When set, commits of the range that changed a component without
+	// being referenced by the release notes are reported as undocumented changes.
+	Clone string
+	// ModCache is the Go module cache with the old and new versions of the component modules.
+	ModCache string
+	// Metadata diffs the metadata.yaml, Options the Config struct and Defaults the default config of the component modules
+	// between the old and new version.
+	Metadata bool
+	Options  bool
+	Defaults bool
+	// APIModules are the modules whose exported Go API is diffed, the API is not analyzed when empty.
+	APIModules []apiModule
+	// Sources are scanned for usages of the API changelog entries, which are not correlated when empty.
+	Sources []string
+}
+
+// includesCategory reports whether the category is analyzed.
+func (o analysisOptions) includesCategory(category string) bool {
+	return len(o.Categories) == 0 || slices.Contains(o.Categories, category)
+}
+
+// includesAudience reports whether the entries of the audience are analyzed.
+func (o analysisOptions) includesAudience(audience string) bool {
+	return o.Audience == "" || o.Audience == audienceAll || o.Audience == audience
+}
+
+// componentNames returns the names a component is referred to by in release notes, the component name first.
+func (o analysisOptions) componentNames(component string) []string {
+	return append([]string{component}, o.Aliases[component]...)
+}
+
+// getComponentChanges retrieves breaking changes, deprecations, and enhancements for specified components across the releases of the range.
+func getComponentChanges(componentsOfInterest []string, repo githubRepo, opts analysisOptions) (map[string]categoryToChangesMap, error) {
+	resolved, err := resolveReleases(opts.Range, repo, opts.Filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get versions: %v", err)
 	}
+	return collectComponentChanges(resolved.Releases, componentsOfInterest, repo, opts)
+}
+
+// collectComponentChanges retrieves the release notes of the releases and keeps the changes of the specified components.
+func collectComponentChanges(releases []release, componentsOfInterest []string, repo githubRepo, opts analysisOptions) (map[string]categoryToChangesMap, error) {
+	releaseNotes, err := fetchReleaseChanges(releases, repo)
+	if err != nil {
+		return nil, err
+	}
+	return filterComponentChanges(releaseNotes, componentsOfInterest, repo, opts), nil
+}
 
-	// Fetch release notes for each version
-	releaseNotes := make(map[string]map[string][]string)
-	for _, ver := range versions {
-		htmlContent, err := fetchReleaseNotes(ver.String(), opentelemetryRepo)
+// fetchReleaseChanges retrieves the changes of the releases grouped by category, keyed by version.
+func fetchReleaseChanges(releases []release, repo githubRepo) (map[string]map[string][]changeEntry, error) {
+	releaseNotes := make(map[string]map[string][]changeEntry)
+	for _, rel := range releases {
+		ver := rel.Version
+		// Releases of a changelog come with their changes
+		if rel.sections != nil {
+			releaseNotes[ver.String()] = rel.sections
+			continue
+		}
+		// Requests are retried by getResponse, a release whose notes still cannot be fetched is left out of the report
+		htmlContent, err := fetchReleaseNotes(rel.Tag, repo)
 		if err != nil {
-			fmt.Printf("Skipping %s due to fetch error: %v\n", ver, err)
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s due to fetch error: %v\n", ver, err)
 			continue
 		}
 		sectionChanges, err := extractReleaseSections(htmlContent)
 		if err != nil {
-			fmt.Printf("Skipping %s due to parsing error: %v\n", ver, err)
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s due to parsing error: %v\n", ver, err)
 			continue
 		}
 		releaseNotes[ver.String()] = sectionChanges
 	}
+	return releaseNotes, nil
+}
 
-	// Initialize the component changes map
+// filterComponentChanges keeps the changes of the specified components in the reported categories.
+// Reverts are collapsed over all sections of the range before the categories and audiences are filtered,
+// so that a revert listed under a skipped section, like bug fixes, still cancels the change it reverts.
+func filterComponentChanges(releaseNotes map[string]map[string][]changeEntry, componentsOfInterest []string, repo githubRepo, opts analysisOptions) map[string]categoryToChangesMap {
+	// Collect the changes of the components in every section, including skipped and filtered ones
 	componentChanges := make(map[string]categoryToChangesMap)
 	for _, component := range componentsOfInterest {
-		componentChanges[component] = categoryToChangesMap{
-			breakingChanges: {},
-			deprecations:    {},
-			enhancements:    {},
-		}
+		componentChanges[component] = categoryToChangesMap{}
 	}
-	// Now filter only those changes that happened on components we care about
 	for ver, sectionChanges := range releaseNotes {
 		for category, changes := range sectionChanges {
 			for _, change := range changes {
+				change.Version = ver
 				for _, component := range componentsOfInterest {
-					// Line has to contain 'component_name:'
-					if strings.Contains(change, fmt.Sprintf("%s:", component)) {
-						componentChanges[component][category] = append(
-							componentChanges[component][category],
-							fmt.Sprintf("%s: %s", ver, change),
-						)
+					// Line has to contain 'component_name:' for the component or one of its aliases
+					for _, name := range opts.componentNames(component) {
+						if mentionsComponent(change.Text, name, repo.isChangelog()) {
+							componentChanges[component][category] = append(
+								componentChanges[component][category],
+								change,
+							)
+							break
+						}
 					}
 				}
 			}
 		}
 	}
-	// Filter out components with no changes in any category
+
 	result := make(map[string]categoryToChangesMap)
-	for component, categories := range componentChanges {
+	for component, changes := range componentChanges {
+		// Sort all categories, merge entries repeated across releases and collapse reverted changes.
+		// Reverts are never merged into the entries they revert, even when citing their PR.
+		categoryOf := make(map[string]string)
+		for category, entries := range changes {
+			sortEntries(entries)
+			changes[category] = dedupeEntries(entries)
+			for _, entry := range changes[category] {
+				categoryOf[entry.Version+"|"+entry.Text] = category
+			}
+		}
+		collapseReverts(changes)
+
+		// Entries of skipped sections, like bug fixes, are only kept to cross-reference commits
+		filtered := categoryToChangesMap{
+			breakingChanges: {},
+			deprecations:    {},
+			enhancements:    {},
+		}
 		hasChanges := false
-		for _, changes := range categories {
-			if len(changes) > 0 {
+		for _, category := range reportedCategories {
+			for _, entry := range changes[category] {
+				// A reverted change is reported when the category it was listed in is
+				entryCategory := category
+				if category == reverted {
+					entryCategory = categoryOf[entry.Version+"|"+entry.Text]
+				}
+				if !slices.Contains(allCategories, entryCategory) || !opts.includesCategory(entryCategory) || !opts.includesAudience(entry.Audience) {
+					continue
+				}
+				filtered[category] = append(filtered[category], entry)
 				hasChanges = true
-				break
 			}
 		}
+		// Filter out components with no changes in any category
 		if hasChanges {
-			result[component] = categories
+			result[component] = filtered
 		}
 	}
+	return result
+}
 
-	// Sort all categories for each component
-	for _, changes := range componentChanges {
-		sort.Strings(changes[breakingChanges])
-		sort.Strings(changes[deprecations])
-		sort.Strings(changes[enhancements])
+// sortEntries sorts entries by semantic version, then by text. Textual sorting would order 0.99.0 after 0.122.0.
+func sortEntries(entries []changeEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if c := compareVersions(entries[i].Version, entries[j].Version); c != 0 {
+			return c < 0
+		}
+		return entries[i].Text < entries[j].Text
+	})
+}
+
+// compareVersions compares two versions semantically, falling back to text for unparseable ones.
+func compareVersions(a, b string) int {
+	verA, errA := parseVersion(a)
+	verB, errB := parseVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
 	}
+	return verA.Compare(verB)
+}
 
-	return result, nil
+// mentionsComponent reports whether an entry is about the component, i.e. contains 'name:', optionally as a code span.
+// Entries of changelogs are rarely prefixed with a component, so anywhere also accepts the component as a code span or as link text, e.g. "Added `osconfigreceiver` receiver".
+func mentionsComponent(text, name string, anywhere bool) bool {
+	if strings.Contains(text, name+":") || strings.Contains(text, "`"+name+"`:") {
+		return true
+	}
+	return anywhere && (strings.Contains(text, "`"+name+"`") || strings.Contains(text, "["+name+"]"))
 }
 
-// Regular expression to match words with dots (e.g., feature gates like receiver.prometheusreceiver.UseCollectorStartTimeFallback)
-var codePattern = regexp.MustCompile(`\b[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)+\b`)
+// formatAudiences writes the categories of a component. When the component has API changelog entries,
+// the entries of each changelog are written in their own sub-section.
+func formatAudiences(builder *strings.Builder, repo githubRepo, categories categoryToChangesMap, withVersion bool) {
+	byAudience := map[string]categoryToChangesMap{audienceUser: {}, audienceAPI: {}}
+	for category, changes := range categories {
+		for _, change := range changes {
+			audience := change.Audience
+			if audience != audienceAPI {
+				audience = audienceUser
+			}
+			byAudience[audience][category] = append(byAudience[audience][category], change)
+		}
+	}
+	if len(byAudience[audienceAPI]) == 0 {
+		formatCategories(builder, repo, categories, withVersion)
+		return
+	}
+	if len(byAudience[audienceUser]) > 0 {
+		builder.WriteString("##### End user changelog\n")
+		formatCategories(builder, repo, byAudience[audienceUser], withVersion)
+	}
+	builder.WriteString("##### API changelog\n")
+	formatCategories(builder, repo, byAudience[audienceAPI], withVersion)
+}
 
-// formatDescription formats a change description by wrapping words with dots in backticks for code formatting.
-func formatDescription(desc string) string {
-	return codePattern.ReplaceAllStringFunc(desc, func(match string) string {
-		return "`" + match + "`"
-	})
+// formatCategories writes the non-empty categories of a component. When withVersion is set, every entry is prefixed with its version.
+func formatCategories(builder *strings.Builder, repo githubRepo, categories categoryToChangesMap, withVersion bool) {
+	for _, category := range reportedCategories {
+		changes := categories[category]
+		if len(changes) == 0 { // Only include categories with changes
+			continue
+		}
+		display := strings.Title(strings.ReplaceAll(category, "_", " "))
+		if category == reverted {
+			display += " (net: no change)"
+		}
+		builder.WriteString(fmt.Sprintf("- **%s**:\n", display))
+		for _, change := range changes {
+			// v0.119.0: cumulativetodeltaprocessor: Add metric type filter for cumulativetodelta processor (#33673)
+			var notes []string
+			if len(change.AlsoIn) > 0 {
+				notes = append(notes, fmt.Sprintf("also in %s", strings.Join(change.AlsoIn, ", ")))
+			}
+			if change.RevertedIn != "" {
+				notes = append(notes, fmt.Sprintf("reverted in %s", change.RevertedIn))
+			}
+			suffix := ""
+			if len(notes) > 0 {
+				suffix = fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+			}
+			// Nested lists and paragraphs of the entry are indented under its list item
+			text := indentContinuation(formatEntryText(repo, change.Text), "    ")
+			if withVersion {
+				builder.WriteString(fmt.Sprintf("  - %s%s: %s\n", change.Version, suffix, text))
+			} else {
+				firstLine, rest, multiline := strings.Cut(text, "\n")
+				builder.WriteString(fmt.Sprintf("  - %s%s\n", firstLine, suffix))
+				if multiline {
+					builder.WriteString(rest + "\n")
+				}
+			}
+		}
+	}
 }
 
-// formatComponentChanges formats the component changes into a Markdown string suitable for GitHub comments, skipping empty categories.
-func formatComponentChanges(opentelemetryRepo string, componentChanges map[string]categoryToChangesMap) string {
-	var blocks []string
+// sortedComponents returns the components of the map in alphabetical order.
+func sortedComponents(componentChanges map[string]categoryToChangesMap) []string {
 	components := make([]string, 0, len(componentChanges))
 	for component := range componentChanges {
 		components = append(components, component)
 	}
 	sort.Strings(components) // Sort components alphabetically
+	return components
+}
 
-	prPattern := regexp.MustCompile(`#(\d+)`) // Matches # followed by digits
-
+// formatComponentChanges formats the component changes into a Markdown string suitable for GitHub comments, skipping empty categories.
+// Components without release note entries are listed too when they have undocumented changes.
+func formatComponentChanges(repo githubRepo, componentChanges map[string]categoryToChangesMap, undocumented map[string][]componentCommit) string {
+	components := sortedComponents(componentChanges)
+	for component := range undocumented {
+		if !slices.Contains(components, component) {
+			components = append(components, component)
+		}
+	}
+	sort.Strings(components)
+	var blocks []string
 	for _, component := range components {
 		var componentBlock strings.Builder
 		componentBlock.WriteString(fmt.Sprintf("#### %s\n", component))
+		formatAudiences(&componentBlock, repo, componentChanges[component], true)
+		formatUndocumentedChanges(&componentBlock, repo, undocumented[component])
+		// Only append the block if it has content beyond the component header
+		if componentBlock.Len() > len(fmt.Sprintf("#### %s\n", component)) {
+			blocks = append(blocks, componentBlock.String())
+		}
+	}
+	// separator between components
+	return strings.Join(blocks, "\n---\n")
+}
+
+// formatVersionChanges formats the component changes into one Markdown section per release in semver order,
+// with its date, its compare link and the entries of the release grouped by component. Undocumented changes follow in their own section,
+// as commits do not belong to a release of the notes.
+func formatVersionChanges(repo githubRepo, releases []release, componentChanges map[string]categoryToChangesMap, undocumented map[string][]componentCommit) string {
+	var blocks []string
+	for _, rel := range releases {
+		var versionBlock strings.Builder
+		versionBlock.WriteString(fmt.Sprintf("### %s", rel.Tag))
+		if !rel.PublishedAt.IsZero() {
+			versionBlock.WriteString(fmt.Sprintf(" (%s)", rel.PublishedAt.Format(time.DateOnly)))
+		}
+		versionBlock.WriteString("\n")
+		if rel.PreviousTag != "" {
+			versionBlock.WriteString(fmt.Sprintf("**Diff**: [%s to %s](%s)\n", rel.PreviousTag, rel.Tag, repo.compareURL(rel.PreviousTag, rel.Tag)))
+		}
 
-		categories := componentChanges[component]
-		for _, category := range []string{breakingChanges, deprecations, enhancements} {
-			changes := categories[category]
-			if len(changes) > 0 { // Only include categories with changes
-				display := strings.Title(strings.ReplaceAll(category, "_", " "))
-				componentBlock.WriteString(fmt.Sprintf("- **%s**:\n", display))
+		hasChanges := false
+		for _, component := range sortedComponents(componentChanges) {
+			categories := make(categoryToChangesMap)
+			for category, changes := range componentChanges[component] {
 				for _, change := range changes {
-					// v0.119.0: cumulativetodeltaprocessor: Add metric type filter for cumulativetodelta processor (#33673)
-					parts := strings.SplitN(change, ": ", 2)
-					if len(parts) == 2 {
-						version, desc := parts[0], parts[1]
-						// Format description with code and PR links
-						formattedDesc := formatDescription(desc)
-						formattedDesc = prPattern.ReplaceAllStringFunc(formattedDesc, func(match string) string {
-							prNum := strings.TrimPrefix(match, "#")
-							return fmt.Sprintf("[#%s](https://github.com/open-telemetry/%s/pull/%s)", prNum, opentelemetryRepo, prNum)
-						})
-						componentBlock.WriteString(fmt.Sprintf("  - %s: %s\n", version, formattedDesc))
-					} else {
-						// Fallback if splitting fails
-						componentBlock.WriteString(fmt.Sprintf("  - %s\n", change))
+					if change.Version == rel.Version.String() {
+						categories[category] = append(categories[category], change)
 					}
 				}
 			}
+			if len(categories) == 0 {
+				continue
+			}
+			hasChanges = true
+			versionBlock.WriteString(fmt.Sprintf("\n#### %s\n", component))
+			formatAudiences(&versionBlock, repo, categories, false)
 		}
-		// Only append the block if it has content beyond the component header
-		if componentBlock.Len() > len(fmt.Sprintf("#### %s\n", component)) {
-			blocks = append(blocks, componentBlock.String())
+		if !hasChanges {
+			versionBlock.WriteString("\nNo changes in the analyzed components.\n")
 		}
+		blocks = append(blocks, versionBlock.String())
 	}
-	// separator between components
+	if len(undocumented) > 0 {
+		var undocumentedBlock strings.Builder
+		undocumentedBlock.WriteString("### Undocumented changes\n")
+		for _, component := range slices.Sorted(maps.Keys(undocumented)) {
+			undocumentedBlock.WriteString(fmt.Sprintf("\n#### %s\n", component))
+			formatUndocumentedChanges(&undocumentedBlock, repo, undocumented[component])
+		}
+		blocks = append(blocks, undocumentedBlock.String())
+	}
+	// separator between releases
 	return strings.Join(blocks, "\n---\n")
 }
 
-// getMessage generates a formatted github formated message listing component changes between two versions. Optionally, encodes to base64.
-func getMessage(oldTag, newTag string, componentsOfInterest []string, opentelemetryRepo string, encode bool) (string, error) {
-	componentChanges, err := getComponentChanges(oldTag, newTag, componentsOfInterest, opentelemetryRepo)
+// sourceReport holds the component changes of a single source repository between two versions.
+type sourceReport struct {
+	Repository string                          `json:"repository"`
+	Old        string                          `json:"old"`
+	New        string                          `json:"new"`
+	CompareURL string                          `json:"compare_url"`
+	Releases   []release                       `json:"releases"`
+	Components map[string]categoryToChangesMap `json:"components"`
+	// Undocumented lists the commits of every component that are not referenced by the release notes, only analyzed with a local clone.
+	Undocumented map[string][]componentCommit `json:"undocumented,omitempty"`
+	// Metadata lists the status changes of the component modules between old and new, only analyzed with the module cache.
+	Metadata map[string]metadataDiff `json:"metadata,omitempty"`
+	// Config lists the removed, renamed and retyped config keys of the component modules between old and new, only analyzed with the module cache.
+	Config map[string][]configChange `json:"config,omitempty"`
+	// Defaults lists the changed default config values of the component modules between old and new, only analyzed with the module cache.
+	Defaults map[string][]defaultChange `json:"defaults,omitempty"`
+	// API lists the Go API changes of the packages of the imported modules of the repository, only analyzed with the module cache.
+	API []packageAPIDiff `json:"api,omitempty"`
+	// Usages lists the API changelog entries of the range whose package paths or identifiers our code uses, only analyzed with sources.
+	Usages []apiUsage `json:"usages,omitempty"`
+
+	repo githubRepo
+}
+
+// buildReport collects the component changes of the repository in the version range of the options.
+func buildReport(componentsOfInterest []string, repo githubRepo, opts analysisOptions) (sourceReport, error) {
+	resolved, err := resolveReleases(opts.Range, repo, opts.Filter)
+	if err != nil {
+		return sourceReport{}, fmt.Errorf("failed to get component changes: failed to get versions: %v", err)
+	}
+	oldTag, newTag, releases := resolved.Old, resolved.New, resolved.Releases
+	releaseNotes, err := fetchReleaseChanges(releases, repo)
 	if err != nil {
-		fmt.Printf("failed to get component changes: %v", err)
-		return "", fmt.Errorf("failed to get component changes: %v", err)
-	}
-	compareURL := fmt.Sprintf("https://github.com/open-telemetry/%s/compare/%s...%s", opentelemetryRepo, oldTag, newTag)
-	// Build the Markdown output
-	markdown := strings.ToUpper(fmt.Sprintf("# %s changes\n", opentelemetryRepo))
-	markdown += fmt.Sprintf("**Diff**: [%s to %s](%s)\n\n", oldTag, newTag, compareURL)
-	markdown += formatComponentChanges(opentelemetryRepo, componentChanges)
+		return sourceReport{}, fmt.Errorf("failed to get component changes: %v", err)
+	}
+	report := sourceReport{
+		Repository: repo.String(),
+		Old:        oldTag,
+		New:        newTag,
+		CompareURL: repo.compareURL(opts.Filter.fullTag(oldTag), opts.Filter.fullTag(newTag)),
+		Releases:   releases,
+		Components: filterComponentChanges(releaseNotes, componentsOfInterest, repo, opts),
+		repo:       repo,
+	}
+	if opts.Clone != "" {
+		// The commits of the first release start after its predecessor, like its release notes
+		first, last := releases[0], releases[len(releases)-1]
+		from := first.PreviousTag
+		if from == "" {
+			from = first.Tag
+		}
+		report.Undocumented, err = undocumentedChanges(opts.Clone, from, last.Tag, componentsOfInterest, opts, documentedPRs(releaseNotes))
+		if err != nil {
+			return sourceReport{}, fmt.Errorf("failed to get undocumented changes: %v", err)
+		}
+	}
+	if len(opts.Sources) > 0 {
+		report.Usages = apiUsages(releaseNotes, scanGoSources(opts.ModCache, opts.Sources), opts)
+	}
+	if (opts.Metadata || opts.Options || opts.Defaults || len(opts.APIModules) > 0) && opts.Range.Chloggen != "" {
+		fmt.Fprintf(os.Stderr, "Warning: the modules of unreleased changes are not in the module cache, skipping metadata and config changes\n")
+	} else {
+		if opts.Metadata {
+			report.Metadata = metadataChanges(opts.ModCache, repo, oldTag, newTag, componentsOfInterest, opts)
+		}
+		if opts.Options {
+			report.Config = configChanges(opts.ModCache, []githubRepo{repo}, oldTag, newTag, componentsOfInterest, opts)
+		}
+		if opts.Defaults {
+			report.Defaults = defaultChanges(opts.ModCache, []githubRepo{repo}, oldTag, newTag, componentsOfInterest, opts)
+		}
+		if modules := repoModules(repo, opts.APIModules); len(modules) > 0 {
+			report.API = apiChanges(opts.ModCache, modules, oldTag, newTag)
+		}
+	}
+	return report, nil
+}
+
+// formatMarkdown formats the report as a github formated message, grouped by component or by version.
+func (r sourceReport) formatMarkdown(groupBy string) string {
+	markdown := strings.ToUpper(fmt.Sprintf("# %s changes\n", r.repo.Name))
+	markdown += fmt.Sprintf("**Diff**: [%s to %s](%s)\n\n", r.Old, r.New, r.CompareURL)
+	if groupBy == groupByVersion {
+		markdown += formatVersionChanges(r.repo, r.Releases, r.Components, r.Undocumented)
+	} else {
+		markdown += formatComponentChanges(r.repo, r.Components, r.Undocumented)
+	}
+	if len(r.Metadata) > 0 {
+		markdown += "\n\n" + formatMetadataChanges(r.Old, r.New, r.Metadata)
+	}
+	if len(r.Config) > 0 {
+		markdown += "\n\n" + formatConfigChanges(r.Old, r.New, r.Config)
+	}
+	if len(r.Defaults) > 0 {
+		markdown += "\n\n" + formatDefaultChanges(r.Old, r.New, r.Defaults)
+	}
+	if len(r.API) > 0 {
+		markdown += "\n\n" + formatAPIChanges(r.Old, r.New, r.API)
+	}
+	if len(r.Usages) > 0 {
+		markdown += "\n\n" + formatAPIUsages(r.repo, r.Old, r.New, r.Usages)
+	}
 	markdown += "\n\n"
+	return markdown
+}
+
+// renderReports renders the reports of all sources in the given format. Optionally, encodes to base64.
+// JSON output is always an array with one report per source.
+func renderReports(reports []sourceReport, opts analysisOptions) (string, error) {
+	var output string
+	switch opts.Format {
+	case "", formatMarkdown:
+		for _, report := range reports {
+			output += report.formatMarkdown(opts.GroupBy)
+		}
+	case formatJSON:
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode report: %v", err)
+		}
+		output = string(data) + "\n"
+	default:
+		return "", fmt.Errorf("unknown output format %q", opts.Format)
+	}
+
+	if opts.Encode {
+		return base64.StdEncoding.EncodeToString([]byte(output)), nil
+	}
+	return output, nil
+}
 
-	if encode {
-		return base64.StdEncoding.EncodeToString([]byte(markdown)), nil
+// getMessage generates a formatted github formated message listing component changes between two versions. Optionally, encodes to base64.
+func getMessage(oldTag, newTag string, componentsOfInterest []string, repo githubRepo, opts analysisOptions) (string, error) {
+	opts.Range = versionRange{Old: oldTag, New: newTag}
+	report, err := buildReport(componentsOfInterest, repo, opts)
+	if err != nil {
+		return "", err
 	}
-	return markdown, nil
+	return renderReports([]sourceReport{report}, opts)
 }
 
 // getComponentsFromGoMod reads the go.mod file, filters lines containing dependencyFilter,
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -41,6 +42,7 @@ func TestGetMessage(t *testing.T) {
 	releasesBody := `[{"tag_name":"v0.122.0","prerelease":false}]`
 	releaseNotesURL := "https://github.com/open-telemetry/opentelemetry-collector-contrib/releases/tag/v0.122.0"
 	nextReleasesURL := "https://api.github.com/repos/open-telemetry/opentelemetry-collector-contrib/releases?per_page=100&page=2"
+	oldReleaseNotesURL := "https://github.com/open-telemetry/opentelemetry-collector-contrib/releases/tag/v0.121.0"
 	releaseNotesBody := `
         <h2>End user changelog</h2>
         <h3>🛑 Breaking changes 🛑</h3>
@@ -60,11 +62,21 @@ Overhaul in document routing.</li>
 				"Link":         []string{linkHeader},
 			},
 		},
+		nextReleasesURL: {
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`[{"tag_name":"v0.121.0","prerelease":false}]`)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		},
 		releaseNotesURL: {
 			StatusCode: 200,
 			Body:       io.NopCloser(strings.NewReader(releaseNotesBody)),
 			Header:     http.Header{"Content-Type": []string{"text/html"}},
 		},
+		oldReleaseNotesURL: {
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader("<h3>🛑 Breaking changes 🛑</h3><ul><li>kafkareceiver: Unrelated (#1)</li></ul>")),
+			Header:     http.Header{"Content-Type": []string{"text/html"}},
+		},
 	}
 
 	originalTransport := client.Transport
@@ -74,10 +86,10 @@ Overhaul in document routing.</li>
 	oldTag := "v0.121.0"
 	newTag := "v0.122.0"
 	componentsOfInterest := []string{"elasticsearchexporter"}
-	repo := "opentelemetry-collector-contrib"
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
 	encode := false
 
-	message, err := getMessage(oldTag, newTag, componentsOfInterest, repo, encode)
+	message, err := getMessage(oldTag, newTag, componentsOfInterest, repo, analysisOptions{Encode: encode})
 	if err != nil {
 		t.Fatalf("getMessage failed: %v", err)
 	}
@@ -88,7 +100,7 @@ Overhaul in document routing.</li>
 #### elasticsearchexporter
 - **Breaking Changes**:
   - 0.122.0: elasticsearchexporter: Dynamically route documents by default unless {logs,metrics,traces}_index is non-empty ([#38361](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/38361))
-             Overhaul in document routing.
+    Overhaul in document routing.
 
 
 `
@@ -97,6 +109,34 @@ Overhaul in document routing.</li>
 	}
 }
 
+func TestGetMessageSkipsUnavailableRelease(t *testing.T) {
+	var attempts int
+	withTestRetryPolicy(t, funcTransport(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		switch req.URL.String() {
+		case "https://api.github.com/repos/open-telemetry/opentelemetry-collector-contrib/releases?per_page=100":
+			body = `[{"tag_name":"v0.122.0","prerelease":false},{"tag_name":"v0.121.0","prerelease":false},{"tag_name":"v0.120.0","prerelease":false}]`
+		case "https://github.com/open-telemetry/opentelemetry-collector-contrib/releases/tag/v0.121.0":
+			attempts++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+		case "https://github.com/open-telemetry/opentelemetry-collector-contrib/releases/tag/v0.122.0":
+			body = "<h3>🛑 Breaking changes 🛑</h3><ul><li>elasticsearchexporter: Drop foo (#2)</li></ul>"
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+
+	message, err := getMessage("v0.120.0", "v0.122.0", []string{"elasticsearchexporter"}, mustParseRepo(t, "opentelemetry-collector-contrib"), analysisOptions{})
+	if err != nil {
+		t.Fatalf("getMessage failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("release notes of v0.121.0 were requested %d times, but we expected 3 attempts", attempts)
+	}
+	if want := "0.122.0: elasticsearchexporter: Drop foo"; !strings.Contains(message, want) {
+		t.Errorf("getMessage result does not contain %q:\n%s", want, message)
+	}
+}
+
 // TestGetComponentsFromGoMod tests the getComponentsFromGoMod function with various scenarios.
 func TestGetComponentsFromGoMod(t *testing.T) {
 	tests := []struct {
@@ -213,3 +253,206 @@ func TestGetComponentsFromGoMod(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersionsBetween(t *testing.T) {
+	releasesURL := "https://api.github.com/repos/open-telemetry/opentelemetry-collector/releases?per_page=100"
+	releasesBody := `[
+		{"tag_name":"v0.123.0-rc.1","prerelease":true},
+		{"tag_name":"v0.122.1","prerelease":false},
+		{"tag_name":"v0.122.0","prerelease":false},
+		{"tag_name":"v0.121.0","prerelease":false},
+		{"tag_name":"cmd/builder/v0.122.1","prerelease":false},
+		{"tag_name":"cmd/builder/v0.121.0","prerelease":false},
+		{"tag_name":"cmd/builder/v0.121.0-rc.2","prerelease":false},
+		{"tag_name":"nightly-build","prerelease":false}
+	]`
+	tests := []struct {
+		name   string
+		old    string
+		new    string
+		filter releaseFilter
+		want   []string
+	}{
+		{
+			name: "root module releases only",
+			old:  "v0.121.0",
+			new:  "v0.122.1",
+			want: []string{"v0.121.0", "v0.122.0", "v0.122.1"},
+		},
+		{
+			name:   "including pre-releases",
+			old:    "v0.122.1",
+			new:    "v0.123.0-rc.1",
+			filter: releaseFilter{IncludePrereleases: true},
+			want:   []string{"v0.122.1", "v0.123.0-rc.1"},
+		},
+		{
+			name:   "sub-module series",
+			old:    "v0.121.0",
+			new:    "cmd/builder/v0.122.1",
+			filter: releaseFilter{TagPrefix: "cmd/builder"},
+			want:   []string{"cmd/builder/v0.121.0", "cmd/builder/v0.122.1"},
+		},
+		{
+			name:   "sub-module series with release candidates tagged as releases",
+			old:    "v0.121.0-rc.2",
+			new:    "v0.121.0",
+			filter: releaseFilter{TagPrefix: "cmd/builder", IncludePrereleases: true},
+			want:   []string{"cmd/builder/v0.121.0-rc.2", "cmd/builder/v0.121.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalTransport := client.Transport
+			client.Transport = &mockTransport{responses: map[string]*http.Response{
+				releasesURL: {
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(releasesBody)),
+					Header:     http.Header{"Link": []string{`<` + releasesURL + `>; rel="first"`}},
+				},
+			}}
+			defer func() { client.Transport = originalTransport }()
+
+			releases, err := getVersionsBetween(tt.old, tt.new, mustParseRepo(t, "opentelemetry-collector"), tt.filter)
+			if err != nil {
+				t.Fatalf("getVersionsBetween() error = %v, want nil", err)
+			}
+			var got []string
+			for _, rel := range releases {
+				got = append(got, rel.Tag)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("getVersionsBetween() returned %v, but we expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortEntriesBySemver(t *testing.T) {
+	entries := []changeEntry{
+		{Version: "0.122.0", Text: "b"},
+		{Version: "0.99.0", Text: "a"},
+		{Version: "0.122.0", Text: "a"},
+		{Version: "0.100.1", Text: "a"},
+	}
+	sortEntries(entries)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Version+" "+entry.Text)
+	}
+	want := []string{"0.99.0 a", "0.100.1 a", "0.122.0 a", "0.122.0 b"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("sortEntries() = %v, but we expected %v", got, want)
+	}
+}
+
+func TestGetMessageGroupByVersion(t *testing.T) {
+	// The dated fixture adds the publication dates and an entry of another component in v0.122.0
+	withReplay(t, "synthetic-contrib-v0.121.0-v0.122.0-dated.json")
+
+	opts := analysisOptions{GroupBy: groupByVersion}
+	message, err := getMessage("v0.121.0", "v0.122.0", []string{"elasticsearchexporter", "prometheusreceiver"}, mustParseRepo(t, "opentelemetry-collector-contrib"), opts)
+	if err != nil {
+		t.Fatalf("getMessage failed: %v", err)
+	}
+
+	expectedSections := []string{
+		"### v0.121.0 (2025-03-04)\n" +
+			"**Diff**: [v0.120.1 to v0.121.0](https://github.com/open-telemetry/opentelemetry-collector-contrib/compare/v0.120.1...v0.121.0)\n" +
+			"\n#### elasticsearchexporter\n" +
+			"- **Enhancements**:\n" +
+			"  - `elasticsearchexporter`: Add",
+		"### v0.122.0 (2025-03-19)\n" +
+			"**Diff**: [v0.121.0 to v0.122.0](https://github.com/open-telemetry/opentelemetry-collector-contrib/compare/v0.121.0...v0.122.0)\n" +
+			"\n#### elasticsearchexporter\n" +
+			"- **Breaking Changes**:\n" +
+			"  - `elasticsearchexporter`: Dynamically route documents",
+		"\n#### prometheusreceiver\n" +
+			"- **Enhancements**:\n" +
+			"  - `prometheusreceiver`: Add",
+	}
+	position := 0
+	for _, section := range expectedSections {
+		index := strings.Index(message[position:], section)
+		if index < 0 {
+			t.Fatalf("getMessage result does not contain %q after position %d:\n%s", section, position, message)
+		}
+		position += index + len(section)
+	}
+}
+
+func TestExtractReleaseSectionsAudience(t *testing.T) {
+	html := `
+		<h3>🛑 Breaking changes 🛑</h3>
+		<ul><li>otlpreceiver: Listed before any changelog header (#1)</li></ul>
+		<h2>API Changelog</h2>
+		<h3>🛑 Breaking changes 🛑</h3>
+		<ul><li>pdata: Remove deprecated Clone function (#2)</li></ul>
+		<h2>End User Changelog</h2>
+		<h3>💡 Enhancements 💡</h3>
+		<ul><li>otlpreceiver: Add compression option (#3)</li></ul>
+	`
+	sections, err := extractReleaseSections(html)
+	if err != nil {
+		t.Fatalf("extractReleaseSections() error = %v", err)
+	}
+	want := map[string][]changeEntry{
+		breakingChanges: {
+			{Text: "otlpreceiver: Listed before any changelog header (#1)", Audience: audienceUser},
+			{Text: "pdata: Remove deprecated Clone function (#2)", Audience: audienceAPI},
+		},
+		enhancements: {
+			{Text: "otlpreceiver: Add compression option (#3)", Audience: audienceUser},
+		},
+	}
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("extractReleaseSections() =\n%+v\nbut we expected\n%+v", sections, want)
+	}
+}
+
+func TestGetMessageAudience(t *testing.T) {
+	components := []string{"elasticsearchexporter", "pkg/ottl"}
+	tests := []struct {
+		audience   string
+		contain    []string
+		notContain []string
+	}{
+		{
+			audience: audienceAll,
+			contain: []string{
+				"#### pkg/ottl\n##### API changelog\n- **Breaking Changes**:\n  - 0.122.0: `pkg/ottl`: Remove",
+				"#### elasticsearchexporter\n- **Breaking Changes**:\n",
+			},
+		},
+		{
+			audience:   audienceUser,
+			contain:    []string{"#### elasticsearchexporter\n"},
+			notContain: []string{"pkg/ottl", "API changelog"},
+		},
+		{
+			audience:   audienceAPI,
+			contain:    []string{"#### pkg/ottl\n##### API changelog\n"},
+			notContain: []string{"elasticsearchexporter"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.audience, func(t *testing.T) {
+			// The API fixture adds an API changelog to the v0.122.0 release notes
+			withReplay(t, "synthetic-contrib-v0.121.0-v0.122.0-api.json")
+			message, err := getMessage("v0.121.0", "v0.122.0", components, mustParseRepo(t, "opentelemetry-collector-contrib"), analysisOptions{Audience: tt.audience})
+			if err != nil {
+				t.Fatalf("getMessage failed: %v", err)
+			}
+			for _, want := range tt.contain {
+				if !strings.Contains(message, want) {
+					t.Errorf("getMessage result does not contain %q:\n%s", want, message)
+				}
+			}
+			for _, unwanted := range tt.notContain {
+				if strings.Contains(message, unwanted) {
+					t.Errorf("getMessage result contains %q:\n%s", unwanted, message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	gotoken "go/token"
+	"go/types"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/apidiff"
+)
+
+// apiModules are the upstream modules whose Go API our SolarWinds contrib code imports.
+var apiModules = []string{
+	"go.opentelemetry.io/collector/pdata",
+	"go.opentelemetry.io/collector/component",
+	"go.opentelemetry.io/collector/consumer",
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl",
+}
+
+// apiModule is a module whose API is compared between two versions.
+type apiModule struct {
+	Path string
+	// Old and New are the module versions, empty when they are resolved from the analyzed release versions.
+	Old string
+	New string
+}
+
+// parseAPIModule parses a module given as path or as path@old..new.
+func parseAPIModule(spec string) (apiModule, error) {
+	modulePath, versions, hasVersions := strings.Cut(spec, "@")
+	if !hasVersions {
+		return apiModule{Path: modulePath}, nil
+	}
+	oldVersion, newVersion, ok := strings.Cut(versions, "..")
+	if !ok || oldVersion == "" || newVersion == "" {
+		return apiModule{}, fmt.Errorf("expected module path or path@old..new, got %q", spec)
+	}
+	return apiModule{Path: modulePath, Old: oldVersion, New: newVersion}, nil
+}
+
+// repoModules returns the modules that belong to a repository, so a report of several repositories diffs every module once.
+func repoModules(repo githubRepo, modules []apiModule) []apiModule {
+	var owned []apiModule
+	for _, module := range modules {
+		if module.Path == repo.modulePath() || strings.HasPrefix(module.Path, repo.modulePath()+"/") {
+			owned = append(owned, module)
+		}
+	}
+	return owned
+}
+
+// resolveModuleVersion finds the version of a module that belongs to a release. Modules tagged with the release, like
+// pkg/ottl@v0.122.0, are used directly. Stable modules have their own versions, like pdata@v1.28.0 of the collector
+// release v0.122.0, they are resolved through the go.mod files of the modules of the release in the module cache,
+// which require them.
+func resolveModuleVersion(modCache, modulePath, releaseVersion string) (string, error) {
+	if _, err := os.Stat(moduleCacheDir(modCache, modulePath, releaseVersion)); err == nil {
+		return releaseVersion, nil
+	}
+	root := filepath.Join(modCache, filepath.FromSlash(escapeModulePath(path.Dir(modulePath))))
+	resolved := ""
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && dir == root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		_, moduleVersion, isModule := strings.Cut(entry.Name(), "@")
+		if !isModule {
+			return nil
+		}
+		if moduleVersion == releaseVersion {
+			if goMod, err := readGoMod(filepath.Join(dir, "go.mod")); err == nil {
+				for _, require := range goMod.Requires {
+					if require.Path == modulePath {
+						resolved = require.Version
+						return fs.SkipAll
+					}
+				}
+			}
+		}
+		// Modules are not nested in the module cache
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search the module cache %s: %v", modCache, err)
+	}
+	if resolved == "" {
+		return "", fmt.Errorf("no module of release %s in the module cache %s requires %s, give its versions as %s@old..new", releaseVersion, modCache, modulePath, modulePath)
+	}
+	return resolved, nil
+}
+
+// packageLoader type-checks the packages of a module version from its sources in the module cache. Imports of the module
+// are type-checked from its own sources, imports of other modules from the versions its go.mod requires, and imports of
+// the standard library with the default importer. Declarations using imports missing in the module cache are left with
+// invalid types.
+type packageLoader struct {
+	fset     *gotoken.FileSet
+	modCache string
+	// modules maps the path of the module and of the modules it requires to their directory in the module cache.
+	modules  map[string]string
+	packages map[string]*types.Package
+	std      types.Importer
+}
+
+func newPackageLoader(modCache, modulePath, moduleDir string) *packageLoader {
+	loader := &packageLoader{
+		fset:     gotoken.NewFileSet(),
+		modCache: modCache,
+		modules:  map[string]string{modulePath: moduleDir},
+		packages: make(map[string]*types.Package),
+		std:      importer.Default(),
+	}
+	// A module without go.mod requires nothing
+	if goMod, err := readGoMod(filepath.Join(moduleDir, "go.mod")); err == nil {
+		for _, require := range goMod.Requires {
+			loader.modules[require.Path] = moduleCacheDir(modCache, require.Path, require.Version)
+		}
+	}
+	return loader
+}
+
+// Import type-checks the package of the import path, see packageLoader.
+func (l *packageLoader) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := l.packages[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", importPath)
+		}
+		return pkg, nil
+	}
+	if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") {
+		return l.std.Import(importPath)
+	}
+	modulePath := ""
+	for candidate := range l.modules {
+		if (importPath == candidate || strings.HasPrefix(importPath, candidate+"/")) && len(candidate) > len(modulePath) {
+			modulePath = candidate
+		}
+	}
+	if modulePath == "" {
+		return nil, fmt.Errorf("no required module provides %s", importPath)
+	}
+	dir := filepath.Join(l.modules[modulePath], filepath.FromSlash(strings.TrimPrefix(importPath, modulePath)))
+	return l.load(importPath, dir)
+}
+
+// load type-checks the package of a directory. Only the declarations are checked, function bodies are skipped.
+// Packages already type-checked, e.g. as imports of other packages, are reused.
+func (l *packageLoader) load(importPath, dir string) (*types.Package, error) {
+	if pkg := l.packages[importPath]; pkg != nil {
+		return pkg, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("package %s not found in the module cache %s", importPath, l.modCache)
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files of %s in %s", importPath, dir)
+	}
+	l.packages[importPath] = nil
+	config := types.Config{Importer: l, IgnoreFuncBodies: true, FakeImportC: true, Error: func(error) {}}
+	pkg, _ := config.Check(importPath, l.fset, files, nil)
+	l.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// loadModulePackages type-checks the packages of a module, keyed by import path. Internal packages, main packages,
+// testdata and nested modules are skipped.
+func loadModulePackages(modCache, moduleDir, modulePath string) (map[string]*types.Package, error) {
+	loader := newPackageLoader(modCache, modulePath, moduleDir)
+	packages := make(map[string]*types.Package)
+	err := filepath.WalkDir(moduleDir, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if dir != moduleDir {
+			if name := entry.Name(); name == "internal" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		rel, err := filepath.Rel(moduleDir, dir)
+		if err != nil {
+			return err
+		}
+		importPath := path.Join(modulePath, filepath.ToSlash(rel))
+		pkg, err := loader.load(importPath, dir)
+		if err != nil || pkg.Name() == "main" {
+			// Directories without Go files are not packages
+			return nil
+		}
+		packages[importPath] = pkg
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the packages of %s: %v", moduleDir, err)
+	}
+	return packages, nil
+}
+
+// packageAPIDiff holds the API changes of a package. Incompatible changes break code using the package, compatible ones do not.
+type packageAPIDiff struct {
+	Package      string   `json:"package"`
+	Module       string   `json:"module"`
+	Old          string   `json:"old"`
+	New          string   `json:"new"`
+	Incompatible []string `json:"incompatible,omitempty"`
+	Compatible   []string `json:"compatible,omitempty"`
+}
+
+// diffModuleAPI compares the API of the packages of a module between two versions with apidiff, which tells compatible
+// changes apart from incompatible ones through the types, e.g. interface{} and any are the same type.
+func diffModuleAPI(module apiModule, old, new map[string]*types.Package) []packageAPIDiff {
+	var diffs []packageAPIDiff
+	for _, pkg := range slices.Sorted(maps.Keys(old)) {
+		diff := packageAPIDiff{Package: pkg, Module: module.Path, Old: module.Old, New: module.New}
+		if newPackage, ok := new[pkg]; ok {
+			for _, change := range apidiff.Changes(old[pkg], newPackage).Changes {
+				if change.Compatible {
+					diff.Compatible = append(diff.Compatible, apiChangeText(change.Message))
+				} else {
+					diff.Incompatible = append(diff.Incompatible, apiChangeText(change.Message))
+				}
+			}
+		} else {
+			diff.Incompatible = []string{"package removed"}
+		}
+		if len(diff.Incompatible) > 0 || len(diff.Compatible) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	for _, pkg := range slices.Sorted(maps.Keys(new)) {
+		if _, ok := old[pkg]; !ok {
+			diffs = append(diffs, packageAPIDiff{Package: pkg, Module: module.Path, Old: module.Old, New: module.New, Compatible: []string{"package added"}})
+		}
+	}
+	slices.SortFunc(diffs, func(a, b packageAPIDiff) int {
+		return strings.Compare(a.Package, b.Package)
+	})
+	return diffs
+}
+
+// apiChangeText writes the object of an apidiff message as code, e.g. `Map.Remove`: removed.
+func apiChangeText(message string) string {
+	object, change, ok := strings.Cut(message, ": ")
+	if !ok {
+		return message
+	}
+	return fmt.Sprintf("`%s`: %s", object, change)
+}
+
+// apiChanges compares the API of the modules between two versions in the module cache. Modules without versions are
+// resolved from the old and new release versions. Modules that are missing are skipped with a warning.
+func apiChanges(modCache string, modules []apiModule, oldVersion, newVersion string) []packageAPIDiff {
+	var diffs []packageAPIDiff
+	for _, module := range modules {
+		if module.Old == "" {
+			var err error
+			if module.Old, err = resolveModuleVersion(modCache, module.Path, oldVersion); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping API of %s: %v\n", module.Path, err)
+				continue
+			}
+			if module.New, err = resolveModuleVersion(modCache, module.Path, newVersion); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping API of %s: %v\n", module.Path, err)
+				continue
+			}
+		}
+		var apis [2]map[string]*types.Package
+		var err error
+		for i, ver := range []string{module.Old, module.New} {
+			dir := moduleCacheDir(modCache, module.Path, ver)
+			if _, err = os.Stat(dir); err != nil {
+				err = fmt.Errorf("module %s@%s not found in the module cache %s, download it with go mod download", module.Path, ver, modCache)
+				break
+			}
+			if apis[i], err = loadModulePackages(modCache, dir, module.Path); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping API of %s: %v\n", module.Path, err)
+			continue
+		}
+		diffs = append(diffs, diffModuleAPI(module, apis[0], apis[1])...)
+	}
+	return diffs
+}
+
+// formatAPIChanges formats the API changes as a dedicated section of the report, one block per package.
+func formatAPIChanges(oldVersion, newVersion string, diffs []packageAPIDiff) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### API changes (%s to %s)\n", oldVersion, newVersion))
+	for _, diff := range diffs {
+		builder.WriteString(fmt.Sprintf("\n#### %s (%s to %s)\n", diff.Package, diff.Old, diff.New))
+		for _, group := range []struct {
+			title   string
+			changes []string
+		}{{"Incompatible", diff.Incompatible}, {"Compatible", diff.Compatible}} {
+			if len(group.changes) == 0 {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("- **%s**:\n", group.title))
+			for _, change := range group.changes {
+				builder.WriteString("  - " + change + "\n")
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const pdataModules = "go.opentelemetry.io/collector/"
+
+// pdataAPIModules are two versions of a fake pdata module, required by the otlpreceiver of the collector releases.
+var pdataAPIModules = map[string]string{
+	pdataModules + "pdata@v1.27.0/go.mod": "module go.opentelemetry.io/collector/pdata\n",
+	pdataModules + "pdata@v1.27.0/pcommon/map.go": `package pcommon
+
+// Map is a mapping of attributes.
+type Map struct {
+	orig *[]KeyValue
+}
+
+type KeyValue struct {
+	Key   string
+	Value string
+	state int
+}
+
+type Getter interface {
+	Get(key string) (string, bool)
+}
+
+type Sealed interface {
+	Len() int
+	private()
+}
+
+func NewMap() Map { return Map{} }
+
+func (m Map) PutStr(k, v string) {}
+
+func (m Map) Remove(key string) bool { return false }
+
+func (m Map) Len() int { return 0 }
+
+func (m *Map) clear() {}
+
+const DefaultCapacity = 8
+`,
+	pdataModules + "pdata@v1.27.0/pcommon/map_test.go":   "package pcommon\n\nfunc TestOnly() {}\n",
+	pdataModules + "pdata@v1.27.0/internal/data/data.go": "package data\n\nfunc Internal() {}\n",
+	pdataModules + "pdata@v1.27.0/pprofile/profile.go":   "package pprofile\n\nfunc NewProfiles() {}\n",
+	pdataModules + "pdata@v1.28.0/go.mod":                "module go.opentelemetry.io/collector/pdata\n",
+	pdataModules + "pdata@v1.28.0/pcommon/map.go": `package pcommon
+
+// Map is a mapping of attributes.
+type Map struct {
+	orig *[]KeyValue
+}
+
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+type Getter interface {
+	Get(key string) (string, bool)
+	Keys() []string
+}
+
+type Sealed interface {
+	Len() int
+	Cap() int
+	private()
+}
+
+func NewMap() Map { return Map{} }
+
+func (m Map) PutStr(key, value string) {}
+
+func (m Map) PutEmptyMap(key string) Map { return Map{} }
+
+func (m Map) Len() int { return 0 }
+
+const DefaultCapacity = 8
+`,
+	pdataModules + "pdata@v1.28.0/plog/logs.go": "package plog\n\nfunc NewLogs[T any](capacity int) (T, error) { var t T; return t, nil }\n",
+	pdataModules + "receiver/otlpreceiver@v0.121.0/go.mod": `module go.opentelemetry.io/collector/receiver/otlpreceiver
+
+require go.opentelemetry.io/collector/pdata v1.27.0
+`,
+	pdataModules + "receiver/otlpreceiver@v0.122.0/go.mod": `module go.opentelemetry.io/collector/receiver/otlpreceiver
+
+require go.opentelemetry.io/collector/pdata v1.28.0
+`,
+}
+
+func TestResolveModuleVersion(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		pdataModules + "receiver/otlpreceiver@v0.122.0/go.mod": "module go.opentelemetry.io/collector/receiver/otlpreceiver\n\nrequire go.opentelemetry.io/collector/pdata v1.28.0\n",
+		contribModules + "pkg/ottl@v0.122.0/go.mod":            "module github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl\n",
+	})
+	tests := []struct {
+		modulePath string
+		release    string
+		want       string
+	}{
+		{contribModules + "pkg/ottl", "v0.122.0", "v0.122.0"},
+		{pdataModules + "pdata", "v0.122.0", "v1.28.0"},
+		{pdataModules + "pdata", "v0.121.0", ""},
+		{pdataModules + "consumer", "v0.122.0", ""},
+	}
+	for _, tt := range tests {
+		got, err := resolveModuleVersion(modCache, tt.modulePath, tt.release)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("resolveModuleVersion(%s, %s) returned %q, but we expected an error", tt.modulePath, tt.release, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveModuleVersion(%s, %s) returned %q, %v, but we expected %q", tt.modulePath, tt.release, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseAPIModule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    apiModule
+		wantErr bool
+	}{
+		{spec: "go.opentelemetry.io/collector/pdata", want: apiModule{Path: "go.opentelemetry.io/collector/pdata"}},
+		{spec: "go.opentelemetry.io/collector/pdata@v1.27.0..v1.28.0", want: apiModule{Path: "go.opentelemetry.io/collector/pdata", Old: "v1.27.0", New: "v1.28.0"}},
+		{spec: "go.opentelemetry.io/collector/pdata@v1.28.0", wantErr: true},
+		{spec: "go.opentelemetry.io/collector/pdata@..v1.28.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseAPIModule(tt.spec)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseAPIModule(%q) returned %+v, %v, but we expected %+v", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestRunAPI(t *testing.T) {
+	modCache := writeModCache(t, pdataAPIModules)
+	args := []string{"api", "--old", "v0.121.0", "--new", "v0.122.0", "--modules", "go.opentelemetry.io/collector/pdata,go.opentelemetry.io/collector/consumer", "--modcache", modCache}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	want := "### API changes (v0.121.0 to v0.122.0)\n\n" +
+		"#### go.opentelemetry.io/collector/pdata/pcommon (v1.27.0 to v1.28.0)\n" +
+		"- **Incompatible**:\n" +
+		"  - `Getter.Keys`: added\n" +
+		"  - `KeyValue.Value`: changed from string to interface{}\n" +
+		"  - `Map.Remove`: removed\n" +
+		"- **Compatible**:\n" +
+		"  - `Map.PutEmptyMap`: added\n" +
+		"  - `Sealed.Cap`: added\n\n" +
+		"#### go.opentelemetry.io/collector/pdata/plog (v1.27.0 to v1.28.0)\n" +
+		"- **Compatible**:\n" +
+		"  - package added\n\n" +
+		"#### go.opentelemetry.io/collector/pdata/pprofile (v1.27.0 to v1.28.0)\n" +
+		"- **Incompatible**:\n" +
+		"  - package removed\n"
+	if stdout.String() != want {
+		t.Errorf("run() printed %q, but we expected %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	explicit := []string{"api", "--old", "v0.121.0", "--new", "v0.122.0", "--modules", "go.opentelemetry.io/collector/pdata@v1.27.0..v1.28.0", "--modcache", modCache, "--format", "json"}
+	if code := run(explicit, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), `"package": "go.opentelemetry.io/collector/pdata/pcommon"`) {
+		t.Errorf("run() = %d, printed unexpected JSON:\n%s", code, stdout.String())
+	}
+
+	stdout.Reset()
+	same := []string{"api", "--old", "v0.122.0", "--new", "v0.122.0", "--modules", "go.opentelemetry.io/collector/pdata", "--modcache", modCache}
+	if code := run(same, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No API changes found for the analyzed modules.") {
+		t.Errorf("run() of the same versions = %d, printed %q", code, stdout.String())
+	}
+
+	stderr.Reset()
+	if code := run([]string{"api", "--old", "v0.121.0", "--new", "v0.122.0", "--modules", "go.opentelemetry.io/collector/pdata@v1.28.0"}, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "expected module path or path@old..new") {
+		t.Errorf("run() with an invalid module = %d, stderr:\n%s", code, stderr.String())
+	}
+}
+
+func TestDiffModuleAPITypes(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		pdataModules + "pdata@v1.27.0/go.mod": "module go.opentelemetry.io/collector/pdata\n",
+		pdataModules + "pdata@v1.27.0/pcommon/value.go": `package pcommon
+
+import "time"
+
+type Value struct{}
+
+func (v Value) AsRaw() interface{} { return nil }
+
+func (v Value) Str() string { return "" }
+
+type Timestamp int64
+
+func (ts Timestamp) AsTime() time.Time { return time.Time{} }
+
+func NewTimestamp(t time.Time) Timestamp { return 0 }
+`,
+		pdataModules + "pdata@v1.28.0/go.mod": "module go.opentelemetry.io/collector/pdata\n",
+		pdataModules + "pdata@v1.28.0/pcommon/value.go": `package pcommon
+
+import "time"
+
+type Value struct {
+	value
+}
+
+type value struct{}
+
+func (v value) Str() string { return "" }
+
+func (v Value) AsRaw() any { return nil }
+
+type Timestamp int64
+
+func NewTimestamp(t time.Duration) Timestamp { return 0 }
+`,
+	})
+	module := apiModule{Path: pdataModules + "pdata", Old: "v1.27.0", New: "v1.28.0"}
+	var packages [2]map[string]*types.Package
+	for i, ver := range []string{module.Old, module.New} {
+		var err error
+		if packages[i], err = loadModulePackages(modCache, moduleCacheDir(modCache, module.Path, ver), module.Path); err != nil {
+			t.Fatalf("loadModulePackages failed: %v", err)
+		}
+	}
+	// interface{} and any are the same type, and a method promoted from an embedded struct is still a method of Value
+	want := []packageAPIDiff{{
+		Package: pdataModules + "pdata/pcommon", Module: module.Path, Old: "v1.27.0", New: "v1.28.0",
+		Incompatible: []string{
+			"`NewTimestamp`: changed from func(time.Time) Timestamp to func(time.Duration) Timestamp",
+			"`Timestamp.AsTime`: removed",
+		},
+	}}
+	if got := diffModuleAPI(module, packages[0], packages[1]); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffModuleAPI() returned\n%+v\nbut we expected\n%+v", got, want)
+	}
+}
+
+func TestLoadModulePackagesOnce(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		pdataModules + "pdata@v1.28.0/go.mod":                  "module go.opentelemetry.io/collector/pdata\n",
+		pdataModules + "pdata@v1.28.0/plog/logs.go":            "package plog\n\nimport \"go.opentelemetry.io/collector/pdata/pmetric\"\n\ntype Logs struct{ Exemplar pmetric.Exemplar }\n",
+		pdataModules + "pdata@v1.28.0/pmetric/exemplar.go":     "package pmetric\n\ntype Exemplar struct{}\n",
+		pdataModules + "pdata@v1.28.0/ptrace/traces.go":        "package ptrace\n\nimport \"go.opentelemetry.io/collector/pdata/pmetric\"\n\ntype Traces struct{ Exemplar pmetric.Exemplar }\n",
+		pdataModules + "pdata@v1.28.0/pmetric/exemplar_doc.go": "package pmetric\n",
+	})
+	packages, err := loadModulePackages(modCache, moduleCacheDir(modCache, pdataModules+"pdata", "v1.28.0"), pdataModules+"pdata")
+	if err != nil {
+		t.Fatalf("loadModulePackages failed: %v", err)
+	}
+	// plog is walked before pmetric and imports it, so pmetric is type-checked as an import first and reused afterwards
+	pmetric := packages[pdataModules+"pdata/pmetric"]
+	for _, name := range []string{"plog", "ptrace"} {
+		imports := packages[pdataModules+"pdata/"+name].Imports()
+		if len(imports) != 1 || imports[0] != pmetric {
+			t.Errorf("%s imports %v, but we expected the loaded pmetric package %p", name, imports, pmetric)
+		}
+	}
+}
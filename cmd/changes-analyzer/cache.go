@@ -0,0 +1,177 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// responseCache is the cache used by getResponse, nil when caching is disabled.
+var responseCache *httpCache
+
+// httpCache stores response bodies on disk keyed by URL and revalidates them with conditional requests.
+type httpCache struct {
+	dir string
+	// offline serves responses only from the cache and never touches the network.
+	offline bool
+}
+
+// cacheEntry is the on-disk representation of a cached response.
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// defaultCacheDir returns the default cache directory under the user cache directory.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "changes-analyzer")
+	}
+	return filepath.Join(dir, "changes-analyzer")
+}
+
+// newHTTPCache creates the cache directory if needed.
+func newHTTPCache(dir string, offline bool) (*httpCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %v", dir, err)
+	}
+	return &httpCache{dir: dir, offline: offline}, nil
+}
+
+// isImmutableURL reports whether the content behind the URL never changes once published.
+// Release pages of a published tag are immutable, release listings are not.
+func isImmutableURL(url string) bool {
+	return strings.Contains(url, "/releases/tag/")
+}
+
+func (c *httpCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *httpCache) load(url string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read cache entry for %s: %v\n", url, err)
+		}
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring corrupted cache entry for %s\n", url)
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *httpCache) store(entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to encode cache entry for %s: %v\n", entry.URL, err)
+		return
+	}
+	// Write to a temporary file first so that an interrupted run never leaves a truncated entry.
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write cache entry for %s: %v\n", entry.URL, err)
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(tmp.Name())
+		fmt.Fprintf(os.Stderr, "Warning: failed to write cache entry for %s: %v\n", entry.URL, err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(entry.URL)); err != nil {
+		os.Remove(tmp.Name())
+		fmt.Fprintf(os.Stderr, "Warning: failed to write cache entry for %s: %v\n", entry.URL, err)
+	}
+}
+
+// response builds a fresh response from the cached entry.
+func (e *cacheEntry) response() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     e.Header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(e.Body)),
+	}
+}
+
+// get returns the response for the URL, using the cached copy when it is still valid.
+func (c *httpCache) get(url string) (*http.Response, error) {
+	entry, cached := c.load(url)
+	if c.offline {
+		if !cached {
+			return nil, fmt.Errorf("offline mode: no cached response for url %s in %s", url, c.dir)
+		}
+		return entry.response(), nil
+	}
+	if cached && isImmutableURL(url) {
+		return entry.response(), nil
+	}
+
+	header := http.Header{}
+	if cached {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	resp, err := fetchWithRetry(url, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return entry.response(), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for url %s: %v", url, err)
+	}
+	entry = &cacheEntry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Header:       resp.Header,
+		Body:         body,
+	}
+	// Mutable content without validators cannot be revalidated, so there is no point in keeping it.
+	if isImmutableURL(url) || entry.ETag != "" || entry.LastModified != "" {
+		c.store(entry)
+	}
+	return entry.response(), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// funcTransport lets a test inspect every request and build the response itself.
+type funcTransport func(req *http.Request) (*http.Response, error)
+
+func (f funcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func readBody(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := getResponse(url)
+	if err != nil {
+		t.Fatalf("getResponse(%s) error = %v, want nil", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
+
+func withTestCache(t *testing.T, transport http.RoundTripper) *httpCache {
+	t.Helper()
+	cache, err := newHTTPCache(t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("newHTTPCache() error = %v", err)
+	}
+	originalTransport, originalCache := client.Transport, responseCache
+	client.Transport = transport
+	responseCache = cache
+	t.Cleanup(func() {
+		client.Transport = originalTransport
+		responseCache = originalCache
+	})
+	return cache
+}
+
+func TestCacheRevalidatesMutableURLs(t *testing.T) {
+	url := "https://api.github.com/repos/open-telemetry/opentelemetry-collector/releases?per_page=100"
+	var requests []*http.Request
+	withTestCache(t, funcTransport(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": []string{`"v1"`}, "Link": []string{`<next>; rel="next"`}},
+			Body:       io.NopCloser(strings.NewReader("releases")),
+		}, nil
+	}))
+
+	if got := readBody(t, url); got != "releases" {
+		t.Errorf("first response body = %q, but we expected %q", got, "releases")
+	}
+	resp, err := getResponse(url)
+	if err != nil {
+		t.Fatalf("getResponse() error = %v, want nil", err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get("Link"); got != `<next>; rel="next"` {
+		t.Errorf("revalidated response lost its Link header, got %q", got)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("made %d requests, but we expected 2", len(requests))
+	}
+	if got := requests[1].Header.Get("If-None-Match"); got != `"v1"` {
+		t.Errorf("second request If-None-Match = %q, but we expected %q", got, `"v1"`)
+	}
+}
+
+func TestCacheServesImmutableURLsWithoutRequest(t *testing.T) {
+	url := "https://github.com/open-telemetry/opentelemetry-collector/releases/tag/v0.122.0"
+	calls := 0
+	withTestCache(t, funcTransport(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("notes"))}, nil
+	}))
+
+	readBody(t, url)
+	if got := readBody(t, url); got != "notes" {
+		t.Errorf("cached response body = %q, but we expected %q", got, "notes")
+	}
+	if calls != 1 {
+		t.Errorf("made %d requests, but we expected 1", calls)
+	}
+}
+
+func TestCacheOffline(t *testing.T) {
+	cached := "https://github.com/open-telemetry/opentelemetry-collector/releases/tag/v0.122.0"
+	missing := "https://github.com/open-telemetry/opentelemetry-collector/releases/tag/v0.123.0"
+	cache := withTestCache(t, funcTransport(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("notes"))}, nil
+	}))
+	readBody(t, cached)
+
+	cache.offline = true
+	client.Transport = funcTransport(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("offline mode made a request to %s", req.URL)
+		return nil, nil
+	})
+	if got := readBody(t, cached); got != "notes" {
+		t.Errorf("offline response body = %q, but we expected %q", got, "notes")
+	}
+	_, err := getResponse(missing)
+	if err == nil || !strings.Contains(err.Error(), "offline mode: no cached response for url "+missing) {
+		t.Errorf("getResponse() error = %v, but we expected an offline cache miss", err)
+	}
+}
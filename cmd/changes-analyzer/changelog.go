@@ -0,0 +1,176 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// changelogRelease is a version section of a markdown changelog.
+type changelogRelease struct {
+	Tag         string
+	PublishedAt time.Time
+	Sections    map[string][]changeEntry
+}
+
+// versionHeadingPattern matches the version headings of markdown changelogs, e.g. "## v0.152.4" or
+// the Keep-a-Changelog "## [1.2.0] - 2024-05-01". Headings without a version, like "## vNext" or "## [Unreleased]", do not match.
+var versionHeadingPattern = regexp.MustCompile(`^##\s+\[?(v?\d+\.\d+\.\d+[0-9A-Za-z.+-]*)\]?(?:\s+-\s+(\d{4}-\d{2}-\d{2}))?\s*$`)
+
+// sectionHeadingPattern matches the headings of sections within a version.
+var sectionHeadingPattern = regexp.MustCompile(`^###\s+(.*?)\s*$`)
+
+// bulletPattern matches a top-level bullet of a section.
+var bulletPattern = regexp.MustCompile(`^[-*+]\s+`)
+
+// skippedSection is the category of sections that are not reported, like bug fixes. Their entries are only kept to cross-reference
+// commits with the changelog.
+const skippedSection = "-"
+
+// changelogSectionCategories maps section headings to categories. Keep-a-Changelog sections are listed together
+// with the sections of upstream changelogs. Entries of unknown sections, and of versions without sections, are categorized by their wording.
+var changelogSectionCategories = map[string]string{
+	"breaking changes": breakingChanges,
+	"removed":          breakingChanges,
+	"deprecations":     deprecations,
+	"deprecated":       deprecations,
+	"enhancements":     enhancements,
+	"new components":   enhancements,
+	"added":            enhancements,
+	"security":         enhancements,
+	"bug fixes":        skippedSection,
+	"fixed":            skippedSection,
+}
+
+// sectionCategory returns the category of a section heading, or an empty string when its entries are categorized by their wording.
+func sectionCategory(heading string) string {
+	// Upstream headings are decorated with emojis, e.g. "🛑 Breaking changes 🛑"
+	normalized := strings.ToLower(strings.Join(strings.FieldsFunc(heading, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+	}), " "))
+	return changelogSectionCategories[normalized]
+}
+
+// breakingWordingPattern matches entries announcing breaking changes, e.g. ":warning: Removes ..." or "BREAKING: ...".
+var breakingWordingPattern = regexp.MustCompile(`(?i)(:warning:|⚠|\bbreaking\b|^(remove[sd]?|drop(s|ped)?)\b)`)
+
+// deprecationWordingPattern matches entries announcing deprecations.
+var deprecationWordingPattern = regexp.MustCompile(`(?i)\bdeprecat`)
+
+// categorizeByWording returns the category of an entry of a flat changelog, which does not group its entries.
+func categorizeByWording(text string) string {
+	switch {
+	case breakingWordingPattern.MatchString(text):
+		return breakingChanges
+	case deprecationWordingPattern.MatchString(text):
+		return deprecations
+	default:
+		return enhancements
+	}
+}
+
+// parseMarkdownChangelog parses a markdown changelog with a "## <version>" heading per release, like our CHANGELOG.md
+// or a Keep-a-Changelog file. Top-level bullets are the entries, indented lines below a bullet, e.g. nested bullets, belong to it.
+func parseMarkdownChangelog(content string) ([]changelogRelease, error) {
+	var releases []changelogRelease
+	var current *changelogRelease
+	category := ""
+	var entry []string
+
+	flush := func() {
+		if current == nil || len(entry) == 0 {
+			entry = nil
+			return
+		}
+		text := strings.TrimSpace(strings.Join(entry, "\n"))
+		entry = nil
+		entryCategory := category
+		if entryCategory == "" {
+			entryCategory = categorizeByWording(text)
+		}
+		if text == "" {
+			return
+		}
+		current.Sections[entryCategory] = append(current.Sections[entryCategory], changeEntry{Text: text, Audience: audienceUser})
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "# "):
+			flush()
+			current = nil
+			category = ""
+			match := versionHeadingPattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			rel := changelogRelease{Tag: match[1], Sections: map[string][]changeEntry{}}
+			if match[2] != "" {
+				published, err := time.Parse(time.DateOnly, match[2])
+				if err != nil {
+					return nil, fmt.Errorf("invalid release date in heading %q: %v", line, err)
+				}
+				rel.PublishedAt = published
+			}
+			releases = append(releases, rel)
+			current = &releases[len(releases)-1]
+		case sectionHeadingPattern.MatchString(line):
+			flush()
+			category = sectionCategory(sectionHeadingPattern.FindStringSubmatch(line)[1])
+		case bulletPattern.MatchString(line):
+			flush()
+			entry = []string{bulletPattern.ReplaceAllString(line, "")}
+		case entry != nil && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.TrimSpace(line) == ""):
+			// Continuation lines are indented to the text of the bullet, the indentation is relative to it in the entry
+			entry = append(entry, trimIndent(line, 2))
+		default:
+			// Text outside of bullets, like an introduction of a version, is not an entry
+			flush()
+		}
+	}
+	flush()
+	return releases, nil
+}
+
+// trimIndent removes up to n leading spaces, a tab counts as a full indentation.
+func trimIndent(line string, n int) string {
+	if strings.HasPrefix(line, "\t") {
+		return line[1:]
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	if removed := len(line) - len(trimmed); removed > n {
+		return line[n:]
+	}
+	return trimmed
+}
+
+// fetchChangelog retrieves and parses the markdown changelog of the repository.
+func fetchChangelog(repo githubRepo) ([]changelogRelease, error) {
+	url := repo.changelogURL()
+	response, err := getResponse(url)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed for url %s: %v", url, err)
+	}
+	defer response.Body.Close()
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changelog body: %v", err)
+	}
+	return parseMarkdownChangelog(string(bodyBytes))
+}
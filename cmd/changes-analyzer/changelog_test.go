@@ -0,0 +1,169 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const solarwindsChangelog = `# Changelog
+
+## vNext
+- Not released yet
+
+## v0.152.4
+- Consumes private solarwinds-otel-collector-contrib v0.152.4 changes:
+  - No changes
+- Changed the ` + "`k8s`" + ` distribution Docker image to use the distroless base image.
+
+## v0.152.2
+- Adds receiver to ` + "`verified`" + ` distribution: [osconfigreceiver](https://github.com/solarwinds/solarwinds-otel-collector-contrib/tree/main/receiver/osconfigreceiver)
+- Deprecates the ` + "`swohostmetricsreceiver`" + ` hostinfo scraper
+
+## v0.152.0
+- :warning: Removes ` + "`dnslookupprocessor`" + ` from the ` + "`playground`" + ` distribution
+`
+
+const keepAChangelog = `# Changelog
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+### Added
+- Something new
+
+## [1.1.0] - 2025-03-01
+### Added
+- ` + "`solarwindsextension`" + `: Add the ` + "`collector_name`" + ` option
+  1. first step
+
+  2. second step
+### Changed
+- BREAKING: ` + "`solarwindsexporter`" + `: Rename ` + "`token`" + ` to ` + "`api_token`" + `
+- Bump dependencies
+### Deprecated
+* Deprecate the ` + "`endpoint`" + ` option
+### Removed
+- Drop Go 1.23 support
+### Fixed
+- Fix crash on shutdown
+
+## [1.0.0] - 2025-01-15
+- Initial release
+`
+
+func TestParseMarkdownChangelog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []changelogRelease
+	}{
+		{
+			name:    "flat bullets under version headings",
+			content: solarwindsChangelog,
+			want: []changelogRelease{
+				{Tag: "v0.152.4", Sections: map[string][]changeEntry{
+					enhancements: {
+						{Text: "Consumes private solarwinds-otel-collector-contrib v0.152.4 changes:\n- No changes", Audience: audienceUser},
+						{Text: "Changed the `k8s` distribution Docker image to use the distroless base image.", Audience: audienceUser},
+					},
+				}},
+				{Tag: "v0.152.2", Sections: map[string][]changeEntry{
+					enhancements: {{Text: "Adds receiver to `verified` distribution: [osconfigreceiver](https://github.com/solarwinds/solarwinds-otel-collector-contrib/tree/main/receiver/osconfigreceiver)", Audience: audienceUser}},
+					deprecations: {{Text: "Deprecates the `swohostmetricsreceiver` hostinfo scraper", Audience: audienceUser}},
+				}},
+				{Tag: "v0.152.0", Sections: map[string][]changeEntry{
+					breakingChanges: {{Text: ":warning: Removes `dnslookupprocessor` from the `playground` distribution", Audience: audienceUser}},
+				}},
+			},
+		},
+		{
+			name:    "keep a changelog sections",
+			content: keepAChangelog,
+			want: []changelogRelease{
+				{Tag: "1.1.0", PublishedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Sections: map[string][]changeEntry{
+					enhancements: {
+						{Text: "`solarwindsextension`: Add the `collector_name` option\n1. first step\n\n2. second step", Audience: audienceUser},
+						{Text: "Bump dependencies", Audience: audienceUser},
+					},
+					breakingChanges: {
+						{Text: "BREAKING: `solarwindsexporter`: Rename `token` to `api_token`", Audience: audienceUser},
+						{Text: "Drop Go 1.23 support", Audience: audienceUser},
+					},
+					deprecations:   {{Text: "Deprecate the `endpoint` option", Audience: audienceUser}},
+					skippedSection: {{Text: "Fix crash on shutdown", Audience: audienceUser}},
+				}},
+				{Tag: "1.0.0", PublishedAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), Sections: map[string][]changeEntry{
+					enhancements: {{Text: "Initial release", Audience: audienceUser}},
+				}},
+			},
+		},
+		{
+			name:    "upstream sections",
+			content: "## v0.122.0\n\n### 🛑 Breaking changes 🛑\n\n- `otlpreceiver`: Remove option (#1)\n\n### 🧰 Bug fixes 🧰\n\n- `otlpreceiver`: Fix crash (#2)\n",
+			want: []changelogRelease{
+				{Tag: "v0.122.0", Sections: map[string][]changeEntry{
+					breakingChanges: {{Text: "`otlpreceiver`: Remove option (#1)", Audience: audienceUser}},
+					// Bug fixes are kept to cross-reference commits, but not reported
+					skippedSection: {{Text: "`otlpreceiver`: Fix crash (#2)", Audience: audienceUser}},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMarkdownChangelog(tt.content)
+			if err != nil {
+				t.Fatalf("parseMarkdownChangelog() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMarkdownChangelog() returned\n%+v\nbut we expected\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMessageFromChangelog(t *testing.T) {
+	changelogURL := "https://github.com/solarwinds/solarwinds-otel-collector-contrib/raw/HEAD/CHANGELOG.md"
+	originalTransport := client.Transport
+	client.Transport = funcTransport(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != changelogURL {
+			return statusResponse(http.StatusNotFound, nil)()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(solarwindsChangelog))}, nil
+	})
+	t.Cleanup(func() { client.Transport = originalTransport })
+
+	repo := mustParseRepo(t, "solarwinds/solarwinds-otel-collector-contrib/CHANGELOG.md")
+	components := []string{"osconfigreceiver", "dnslookupprocessor", "swohostmetricsreceiver"}
+	message, err := getMessage("v0.152.0", "v0.152.4", components, repo, analysisOptions{})
+	if err != nil {
+		t.Fatalf("getMessage failed: %v", err)
+	}
+	for _, want := range []string{
+		"# SOLARWINDS-OTEL-COLLECTOR-CONTRIB CHANGES\n**Diff**: [v0.152.0 to v0.152.4](https://github.com/solarwinds/solarwinds-otel-collector-contrib/compare/v0.152.0...v0.152.4)\n",
+		"#### dnslookupprocessor\n- **Breaking Changes**:\n  - 0.152.0: :warning: Removes `dnslookupprocessor` from the `playground` distribution\n",
+		"#### osconfigreceiver\n- **Enhancements**:\n  - 0.152.2: Adds receiver to `verified` distribution: [osconfigreceiver](",
+		"#### swohostmetricsreceiver\n- **Deprecations**:\n  - 0.152.2: Deprecates the `swohostmetricsreceiver` hostinfo scraper\n",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("getMessage result does not contain %q:\n%s", want, message)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"go.yaml.in/yaml/v3"
+)
+
+const chloggenDir = ".chloggen"
+
+// unreleasedSuffix marks the version of the pending changes, e.g. 0.123.0-unreleased.
+const unreleasedSuffix = "unreleased"
+
+// chloggenEntry is a pending changelog entry of upstream, one YAML file in the .chloggen directory.
+type chloggenEntry struct {
+	ChangeType string   `yaml:"change_type"`
+	Component  string   `yaml:"component"`
+	Note       string   `yaml:"note"`
+	Issues     []int    `yaml:"issues"`
+	Subtext    string   `yaml:"subtext"`
+	ChangeLogs []string `yaml:"change_logs"`
+}
+
+// chloggenCategories maps change types to categories. Bug fixes are not reported, like in release pages.
+var chloggenCategories = map[string]string{
+	"breaking":      breakingChanges,
+	"deprecation":   deprecations,
+	"enhancement":   enhancements,
+	"new_component": enhancements,
+	"bug_fix":       skippedSection,
+}
+
+// text renders the entry like the upstream changelog template does, e.g. "`receiver/prometheus`: Add option (#1, #2)",
+// followed by the subtext.
+func (e chloggenEntry) text() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("`%s`: %s", strings.TrimSpace(e.Component), strings.TrimSpace(e.Note)))
+	if len(e.Issues) > 0 {
+		issues := make([]string, 0, len(e.Issues))
+		for _, issue := range e.Issues {
+			issues = append(issues, fmt.Sprintf("#%d", issue))
+		}
+		builder.WriteString(fmt.Sprintf(" (%s)", strings.Join(issues, ", ")))
+	}
+	if subtext := strings.TrimSpace(e.Subtext); subtext != "" {
+		builder.WriteString("\n" + subtext)
+	}
+	return builder.String()
+}
+
+// audiences returns the changelogs the entry is listed in. Entries without change_logs are for end users.
+func (e chloggenEntry) audiences() []string {
+	var audiences []string
+	if len(e.ChangeLogs) == 0 || slices.Contains(e.ChangeLogs, audienceUser) {
+		audiences = append(audiences, audienceUser)
+	}
+	if slices.Contains(e.ChangeLogs, audienceAPI) {
+		audiences = append(audiences, audienceAPI)
+	}
+	return audiences
+}
+
+// chloggenEntriesDir returns the .chloggen directory of a local clone. The directory itself may be given as well.
+func chloggenEntriesDir(clone string) (string, error) {
+	for _, dir := range []string{filepath.Join(clone, chloggenDir), clone} {
+		if filepath.Base(dir) != chloggenDir {
+			continue
+		}
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a local clone with a %s directory", clone, chloggenDir)
+}
+
+// readChloggenSections reads the pending entries of a local clone and groups them by category.
+// The template and the configuration of chloggen are skipped, as are files that cannot be parsed, with a warning.
+func readChloggenSections(clone string) (map[string][]changeEntry, error) {
+	dir, err := chloggenEntriesDir(clone)
+	if err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", dir, err)
+	}
+
+	sections := make(map[string][]changeEntry)
+	for _, file := range files {
+		name := file.Name()
+		ext := filepath.Ext(name)
+		if file.IsDir() || (ext != ".yaml" && ext != ".yml") || strings.EqualFold(name, "TEMPLATE.yaml") || name == "config.yaml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", name, err)
+		}
+		var entry chloggenEntry
+		if err := yaml.Unmarshal(data, &entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", name, err)
+			continue
+		}
+		if entry.Component == "" || entry.Note == "" {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s without component or note\n", name)
+			continue
+		}
+		category, ok := chloggenCategories[strings.TrimSpace(entry.ChangeType)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s with unknown change_type %q\n", name, entry.ChangeType)
+			continue
+		}
+		for _, audience := range entry.audiences() {
+			sections[category] = append(sections[category], changeEntry{Text: entry.text(), Audience: audience})
+		}
+	}
+	// Directory order is alphabetical, but entries are sorted by their text like the upstream changelog
+	for _, entries := range sections {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Text < entries[j].Text })
+	}
+	return sections, nil
+}
+
+// latestChangelogVersion returns the tag and version of the newest release in the CHANGELOG.md of a local clone.
+func latestChangelogVersion(clone string) (string, *version.Version, error) {
+	data, err := os.ReadFile(filepath.Join(clone, "CHANGELOG.md"))
+	if err != nil {
+		return "", nil, err
+	}
+	releases, err := parseMarkdownChangelog(string(data))
+	if err != nil {
+		return "", nil, err
+	}
+	var latestTag string
+	var latest *version.Version
+	for _, rel := range releases {
+		ver, err := parseVersion(rel.Tag)
+		if err != nil {
+			continue
+		}
+		if latest == nil || ver.GreaterThan(latest) {
+			latestTag, latest = rel.Tag, ver
+		}
+	}
+	if latest == nil {
+		return "", nil, fmt.Errorf("no release found in CHANGELOG.md")
+	}
+	return latestTag, latest, nil
+}
+
+// cloneRef returns the branch checked out in a local clone, or the commit of a detached HEAD.
+func cloneRef(clone string) string {
+	gitDir := filepath.Join(clone, ".git")
+	// Worktrees and submodules have a .git file pointing at the git directory
+	if data, err := os.ReadFile(gitDir); err == nil {
+		if dir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: "); ok {
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(clone, dir)
+			}
+			gitDir = dir
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "HEAD"
+	}
+	head := strings.TrimSpace(string(data))
+	if ref, ok := strings.CutPrefix(head, "ref: refs/heads/"); ok {
+		return ref
+	}
+	return head
+}
+
+// pendingRelease returns the pending changes of a local clone as the release following the newest release of its CHANGELOG.md,
+// e.g. 0.123.0-unreleased after v0.122.0. Its tag is the checked out branch, so that the diff compares it with the newest release.
+func pendingRelease(clone string) (release, error) {
+	sections, err := readChloggenSections(clone)
+	if err != nil {
+		return release{}, err
+	}
+	previousTag, latest, err := latestChangelogVersion(clone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cannot determine the released version of %s, the pending changes are analyzed as version 0.0.0: %v\n", clone, err)
+		latest, previousTag = version.Must(version.NewVersion("0.0.0")), ""
+	}
+	segments := latest.Segments()
+	next := version.Must(version.NewVersion(fmt.Sprintf("%d.%d.0-%s", segments[0], segments[1]+1, unreleasedSuffix)))
+	return release{Tag: cloneRef(clone), Version: next, PreviousTag: previousTag, sections: sections}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeClone writes a local clone with the given files, relative to its root, and returns its path.
+func writeClone(t *testing.T, files map[string]string) string {
+	t.Helper()
+	clone := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(clone, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return clone
+}
+
+var chloggenClone = map[string]string{
+	".git/HEAD":               "ref: refs/heads/main\n",
+	"CHANGELOG.md":            "# Changelog\n\n<!-- next version -->\n\n## v0.122.0\n\n### 💡 Enhancements 💡\n\n- `receiver/prometheus`: Add option (#1)\n\n## v0.121.0\n",
+	".chloggen/TEMPLATE.yaml": "change_type:\ncomponent:\nnote:\nissues: []\n",
+	".chloggen/config.yaml":   "entries_dir: .chloggen\n",
+	".chloggen/es-mapping.yaml": `change_type: breaking
+component: elasticsearchexporter
+note: Remove the deprecated mapping modes
+issues: [38000, 38001]
+subtext: |
+  Use ` + "`mapping::mode`" + ` instead.
+`,
+	".chloggen/prom-fix.yaml":    "change_type: bug_fix\ncomponent: receiver/prometheus\nnote: Fix a panic\nissues: [38002]\n",
+	".chloggen/prom-option.yaml": "change_type: enhancement\ncomponent: receiver/prometheus\nnote: Add the scrape_on_start option\nissues: [38003]\n",
+	".chloggen/ottl-api.yaml":    "change_type: deprecation\ncomponent: pkg/ottl\nnote: Deprecate the Parse function\nissues: [38004]\nchange_logs: [api]\n",
+	".chloggen/broken.yaml":      "change_type: [breaking\n",
+}
+
+func TestReadChloggenSections(t *testing.T) {
+	clone := writeClone(t, chloggenClone)
+	sections, err := readChloggenSections(clone)
+	if err != nil {
+		t.Fatalf("readChloggenSections failed: %v", err)
+	}
+	expected := map[string][]changeEntry{
+		breakingChanges: {{Text: "`elasticsearchexporter`: Remove the deprecated mapping modes (#38000, #38001)\nUse `mapping::mode` instead.", Audience: audienceUser}},
+		deprecations:    {{Text: "`pkg/ottl`: Deprecate the Parse function (#38004)", Audience: audienceAPI}},
+		enhancements:    {{Text: "`receiver/prometheus`: Add the scrape_on_start option (#38003)", Audience: audienceUser}},
+		skippedSection:  {{Text: "`receiver/prometheus`: Fix a panic (#38002)", Audience: audienceUser}},
+	}
+	if !reflect.DeepEqual(sections, expected) {
+		t.Errorf("readChloggenSections() returned %#v, but we expected %#v", sections, expected)
+	}
+
+	// The .chloggen directory can be given instead of the clone
+	fromDir, err := readChloggenSections(filepath.Join(clone, chloggenDir))
+	if err != nil || !reflect.DeepEqual(fromDir, expected) {
+		t.Errorf("readChloggenSections() of the .chloggen directory returned %#v, %v, but we expected %#v", fromDir, err, expected)
+	}
+
+	if _, err := readChloggenSections(t.TempDir()); err == nil || !strings.Contains(err.Error(), "is not a local clone with a .chloggen directory") {
+		t.Errorf("readChloggenSections() of a directory without entries returned %v, but we expected an error", err)
+	}
+}
+
+func TestPendingRelease(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		wantTag         string
+		wantVersion     string
+		wantPreviousTag string
+	}{
+		{
+			name:            "branch after the latest release",
+			files:           chloggenClone,
+			wantTag:         "main",
+			wantVersion:     "0.123.0-unreleased",
+			wantPreviousTag: "v0.122.0",
+		},
+		{
+			name: "detached worktree",
+			files: map[string]string{
+				".git":                 "gitdir: worktree-git\n",
+				"worktree-git/HEAD":    "0123abcd\n",
+				"CHANGELOG.md":         "## v1.28.1\n\n## v1.29.0\n",
+				".chloggen/entry.yaml": "change_type: enhancement\ncomponent: pdata\nnote: Add a method\n",
+			},
+			wantTag:         "0123abcd",
+			wantVersion:     "1.30.0-unreleased",
+			wantPreviousTag: "v1.29.0",
+		},
+		{
+			name:            "without changelog",
+			files:           map[string]string{".chloggen/entry.yaml": "change_type: enhancement\ncomponent: pdata\nnote: Add a method\n"},
+			wantTag:         "HEAD",
+			wantVersion:     "0.1.0-unreleased",
+			wantPreviousTag: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel, err := pendingRelease(writeClone(t, tt.files))
+			if err != nil {
+				t.Fatalf("pendingRelease failed: %v", err)
+			}
+			if rel.Tag != tt.wantTag || rel.Version.String() != tt.wantVersion || rel.PreviousTag != tt.wantPreviousTag {
+				t.Errorf("pendingRelease() returned %s %s after %q, but we expected %s %s after %q",
+					rel.Tag, rel.Version, rel.PreviousTag, tt.wantTag, tt.wantVersion, tt.wantPreviousTag)
+			}
+		})
+	}
+}
+
+func TestBuildReportFromChloggen(t *testing.T) {
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	opts := analysisOptions{
+		Range:   versionRange{Chloggen: writeClone(t, chloggenClone)},
+		Aliases: map[string][]string{"prometheusreceiver": {"receiver/prometheus"}},
+	}
+	report, err := buildReport([]string{"elasticsearchexporter", "prometheusreceiver"}, repo, opts)
+	if err != nil {
+		t.Fatalf("buildReport failed: %v", err)
+	}
+	message := report.formatMarkdown(groupByComponent)
+	for _, want := range []string{
+		"**Diff**: [v0.122.0 to main](https://github.com/open-telemetry/opentelemetry-collector-contrib/compare/v0.122.0...main)\n",
+		"#### elasticsearchexporter\n- **Breaking Changes**:\n  - 0.123.0-unreleased: `elasticsearchexporter`: Remove the deprecated mapping modes " +
+			"([#38000](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/38000), " +
+			"[#38001](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/38001))\n    Use `mapping::mode` instead.\n",
+		"#### prometheusreceiver\n- **Enhancements**:\n  - 0.123.0-unreleased: `receiver/prometheus`: Add the `scrape_on_start` option",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("report does not contain %q:\n%s", want, message)
+		}
+	}
+	if strings.Contains(message, "Fix a panic") {
+		t.Errorf("report contains a bug fix:\n%s", message)
+	}
+}
@@ -0,0 +1,780 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// commaList is a list flag given as comma-separated values on the command line and as a list in the config file.
+type commaList []string
+
+func (l *commaList) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *commaList) Set(value string) error {
+	*l = nil
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// UnmarshalYAML accepts both a YAML list and a comma-separated string.
+func (l *commaList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return l.Set(node.Value)
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// aliasMap maps a component to other names it is referred to by in release notes.
+// On the command line it is given as a repeatable component=alias1|alias2 flag.
+type aliasMap map[string]commaList
+
+func (m *aliasMap) String() string {
+	if m == nil {
+		return ""
+	}
+	var entries []string
+	for component, aliases := range *m {
+		entries = append(entries, component+"="+strings.Join(aliases, "|"))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+func (m *aliasMap) Set(value string) error {
+	component, aliases, ok := strings.Cut(value, "=")
+	if !ok || component == "" || aliases == "" {
+		return fmt.Errorf("expected component=alias1|alias2, got %q", value)
+	}
+	if *m == nil {
+		*m = aliasMap{}
+	}
+	(*m)[component] = strings.Split(aliases, "|")
+	return nil
+}
+
+// settings holds all options of all subcommands. The yaml keys of the config file match the flag names.
+type settings struct {
+	Config  string `yaml:"-"`
+	Profile string `yaml:"-"`
+
+	Old string `yaml:"old"`
+	New string `yaml:"new"`
+	// Range, Since and Until select the releases by version range expression and publication dates instead of Old and New.
+	Range string `yaml:"range"`
+	Since string `yaml:"since"`
+	Until string `yaml:"until"`
+	// Chloggen is a local clone whose pending .chloggen entries are analyzed instead of released versions.
+	Chloggen string `yaml:"chloggen"`
+	// Repo lists the source repositories, a report covers all of them.
+	Repo       commaList `yaml:"repo"`
+	WebBaseURL string    `yaml:"webBaseURL"`
+	APIBaseURL string    `yaml:"apiBaseURL"`
+	Prerelease bool      `yaml:"prerelease"`
+	TagPrefix  string    `yaml:"tagPrefix"`
+
+	Components       commaList `yaml:"components"`
+	GoModPath        commaList `yaml:"goModPath"`
+	DependencyFilter commaList `yaml:"dependencyFilter"`
+	Aliases          aliasMap  `yaml:"aliases"`
+
+	Categories commaList `yaml:"categories"`
+	Audience   string    `yaml:"audience"`
+	Format     string    `yaml:"format"`
+	GroupBy    string    `yaml:"group-by"`
+	Encode     bool      `yaml:"encode"`
+	// Clone is a local clone of the repo, whose history is searched for undocumented component changes.
+	Clone string `yaml:"clone"`
+	// Metadata, Options and Defaults diff the metadata.yaml, the Config struct and the default config of the component
+	// modules in the module cache, ModCache defaults to the one of go.
+	Metadata bool   `yaml:"metadata"`
+	Options  bool   `yaml:"options"`
+	Defaults bool   `yaml:"defaults"`
+	ModCache string `yaml:"modcache"`
+	// API diffs the exported Go API of the Modules we import, given as path or path@old..new.
+	API     bool      `yaml:"api"`
+	Modules commaList `yaml:"modules"`
+	// Sources are the directories of our Go code, or go.mod files whose own modules are scanned in the module cache,
+	// for usages of the APIs of the API changelog entries.
+	Sources commaList `yaml:"sources"`
+	// Examples is the directory of our example configs, which are searched for the telemetry changed upstream.
+	Examples string `yaml:"examples"`
+	// Verified is the generated list of the components of the verified distribution, the example configs are linted against it.
+	Verified string    `yaml:"verified"`
+	FailOn   commaList `yaml:"failOn"`
+
+	CacheDir        string        `yaml:"cache-dir"`
+	NoCache         bool          `yaml:"no-cache"`
+	Offline         bool          `yaml:"offline"`
+	Record          string        `yaml:"record"`
+	Replay          string        `yaml:"replay"`
+	Timeout         time.Duration `yaml:"timeout"`
+	Retries         int           `yaml:"retries"`
+	RetryBackoff    time.Duration `yaml:"retryBackoff"`
+	RetryMaxBackoff time.Duration `yaml:"retryMaxBackoff"`
+}
+
+func defaultSettings() settings {
+	policy := defaultRetryPolicy()
+	return settings{
+		Format:          formatMarkdown,
+		Examples:        "../../examples/integrations",
+		Verified:        "../../docs/verified-components.md",
+		Modules:         slices.Clone(apiModules),
+		GroupBy:         groupByComponent,
+		Audience:        audienceAll,
+		FailOn:          commaList{breakingChanges},
+		CacheDir:        defaultCacheDir(),
+		Timeout:         policy.Timeout,
+		Retries:         policy.MaxRetries,
+		RetryBackoff:    policy.InitialBackoff,
+		RetryMaxBackoff: policy.MaxBackoff,
+	}
+}
+
+// usageError is returned for invalid invocations, the usage of the subcommand is printed with it.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// errCheckFailed is returned by the check subcommand when it finds changes in the failOn categories.
+var errCheckFailed = errors.New("check failed")
+
+// command is a single subcommand of the CLI.
+type command struct {
+	name        string
+	description string
+	// flags registers the flags of the subcommand on top of the shared --config flag.
+	flags func(fs *flag.FlagSet, s *settings)
+	run   func(s *settings, out io.Writer) error
+}
+
+var commands = []command{
+	{
+		name:        "report",
+		description: "Generate the Markdown report of component changes between two versions",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			releaseFlags(fs, s)
+			componentFlags(fs, s)
+			fs.StringVar(&s.Audience, "audience", s.Audience, "Changelog audience to analyze: user (end user changelog), api (API changelog) or all")
+			fs.Var(&s.Categories, "categories", "Comma-separated categories to report (breaking_changes, deprecations, enhancements), all when empty")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+			fs.StringVar(&s.GroupBy, "group-by", s.GroupBy, "Sections of the markdown report, component or version")
+			fs.BoolVar(&s.Encode, "encode", s.Encode, "Whether to base64 encode the output")
+			fs.StringVar(&s.Clone, "clone", s.Clone, "Local clone of the repo to report commits of the components missing from the release notes")
+			fs.BoolVar(&s.Metadata, "metadata", s.Metadata, "Report stability, status and codeowner changes from the metadata.yaml of the component modules")
+			fs.BoolVar(&s.Options, "options", s.Options, "Report removed, renamed and retyped config keys from the Config struct of the component modules")
+			fs.BoolVar(&s.Defaults, "defaults", s.Defaults, "Report changed default config values by building the factories of the component modules")
+			fs.BoolVar(&s.API, "api", s.API, "Report incompatible and compatible Go API changes of the modules we import")
+			fs.Var(&s.Modules, "modules", "Comma-separated modules whose API is diffed, as path or path@old..new (default pdata, component, consumer and pkg/ottl)")
+			fs.Var(&s.Sources, "sources", "Comma-separated directories of our Go code, or go.mod files whose github.com/solarwinds modules are scanned, to flag the API changelog entries they use")
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the old and new component modules (default GOMODCACHE)")
+			httpFlags(fs, s)
+		},
+		run: runReport,
+	},
+	{
+		name:        "components",
+		description: "List the components that would be analyzed",
+		flags:       componentFlags,
+		run:         runComponents,
+	},
+	{
+		name:        "versions",
+		description: "List the releases in the resolved version range",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			releaseFlags(fs, s)
+			httpFlags(fs, s)
+		},
+		run: runVersions,
+	},
+	{
+		name:        "gates",
+		description: "List feature gates mentioned in the changes of the analyzed components",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			releaseFlags(fs, s)
+			componentFlags(fs, s)
+			fs.StringVar(&s.Audience, "audience", s.Audience, "Changelog audience to analyze: user (end user changelog), api (API changelog) or all")
+			httpFlags(fs, s)
+		},
+		run: runGates,
+	},
+	{
+		name:        "check",
+		description: "Fail with exit code 2 when the analyzed components have changes in the failOn categories",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			releaseFlags(fs, s)
+			componentFlags(fs, s)
+			fs.StringVar(&s.Audience, "audience", s.Audience, "Changelog audience to analyze: user (end user changelog), api (API changelog) or all")
+			fs.Var(&s.FailOn, "failOn", "Comma-separated categories that fail the check (breaking_changes, deprecations, enhancements)")
+			httpFlags(fs, s)
+		},
+		run: runCheck,
+	},
+	{
+		name:        "telemetry",
+		description: "Diff the metrics, resource attributes and events of the component modules between two versions",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			fs.StringVar(&s.Old, "old", s.Old, "Old module version (e.g., v0.121.0)")
+			fs.StringVar(&s.New, "new", s.New, "New module version (e.g., v0.122.0)")
+			fs.Var(&s.Repo, "repo", "Comma-separated GitHub repositories whose module paths contain the components")
+			componentFlags(fs, s)
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the old and new component modules (default GOMODCACHE)")
+			fs.StringVar(&s.Examples, "examples", s.Examples, "Directory of the example configs searched for the changed telemetry")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+		},
+		run: runTelemetry,
+	},
+	{
+		name:        "options",
+		description: "Diff the config keys of the Config structs of the component modules between two versions",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			fs.StringVar(&s.Old, "old", s.Old, "Old module version (e.g., v0.121.0)")
+			fs.StringVar(&s.New, "new", s.New, "New module version (e.g., v0.122.0)")
+			fs.Var(&s.Repo, "repo", "Comma-separated GitHub repositories whose module paths contain the components")
+			componentFlags(fs, s)
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the old and new component modules (default GOMODCACHE)")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+		},
+		run: runOptions,
+	},
+	{
+		name:        "defaults",
+		description: "Diff the default configs of the component modules between two versions by building their factories",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			fs.StringVar(&s.Old, "old", s.Old, "Old module version (e.g., v0.121.0)")
+			fs.StringVar(&s.New, "new", s.New, "New module version (e.g., v0.122.0)")
+			fs.Var(&s.Repo, "repo", "Comma-separated GitHub repositories whose module paths contain the components")
+			componentFlags(fs, s)
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the old and new component modules and their dependencies (default GOMODCACHE)")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+		},
+		run: runDefaults,
+	},
+	{
+		name:        "api",
+		description: "Diff the exported Go API of the modules we import between two versions",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			fs.StringVar(&s.Old, "old", s.Old, "Old release version (e.g., v0.121.0), stable modules are resolved from the modules of the release")
+			fs.StringVar(&s.New, "new", s.New, "New release version (e.g., v0.122.0), stable modules are resolved from the modules of the release")
+			fs.Var(&s.Modules, "modules", "Comma-separated modules whose API is diffed, as path or path@old..new (default pdata, component, consumer and pkg/ottl)")
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the old and new modules (default GOMODCACHE)")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+		},
+		run: runAPI,
+	},
+	{
+		name:        "usages",
+		description: "Flag the API changelog entries whose package paths or identifiers our Go code uses",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			releaseFlags(fs, s)
+			fs.Var(&s.Categories, "categories", "Comma-separated categories to correlate (breaking_changes, deprecations, enhancements), all when empty")
+			fs.Var(&s.Sources, "sources", "Comma-separated directories of our Go code, or go.mod files whose github.com/solarwinds modules are scanned")
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the modules of the go.mod sources (default GOMODCACHE)")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+			httpFlags(fs, s)
+		},
+		run: runUsages,
+	},
+	{
+		name:        "lint",
+		description: "Check the example configs against the metadata.yaml and Config struct of the pinned component modules",
+		flags: func(fs *flag.FlagSet, s *settings) {
+			fs.Var(&s.GoModPath, "goModPath", "Comma-separated paths to the go.mod files pinning the component versions (e.g., /app/go.mod)")
+			fs.StringVar(&s.ModCache, "modcache", s.ModCache, "Go module cache with the pinned component modules (default GOMODCACHE)")
+			fs.StringVar(&s.Examples, "examples", s.Examples, "Directory of the example configs to lint")
+			fs.StringVar(&s.Verified, "verified", s.Verified, "Generated list of the components of the verified distribution")
+			fs.StringVar(&s.Format, "format", s.Format, "Output format, markdown or json")
+		},
+		run: runLint,
+	},
+}
+
+func releaseFlags(fs *flag.FlagSet, s *settings) {
+	fs.StringVar(&s.Old, "old", s.Old, "Old version tag (e.g., v0.119.0), latest or latest-minor")
+	fs.StringVar(&s.New, "new", s.New, "New version tag (e.g., v0.121.0), latest or latest-minor (default latest)")
+	fs.StringVar(&s.Range, "range", s.Range, "Version range expression instead of old and new (e.g., \">=0.119.0,<0.123.0\")")
+	fs.StringVar(&s.Since, "since", s.Since, "Analyze the releases published since the date (e.g., 2026-08-01)")
+	fs.StringVar(&s.Until, "until", s.Until, "Analyze the releases published until the date, included (default today)")
+	fs.StringVar(&s.Chloggen, "chloggen", s.Chloggen, "Local clone of the repo whose unreleased .chloggen entries are analyzed instead of releases")
+	fs.Var(&s.Repo, "repo", "Comma-separated GitHub repositories, each as name (owned by open-telemetry), owner/name or full URL")
+	fs.StringVar(&s.WebBaseURL, "webBaseURL", s.WebBaseURL, "Base URL of the GitHub web UI (default derived from repo, otherwise https://github.com)")
+	fs.StringVar(&s.APIBaseURL, "apiBaseURL", s.APIBaseURL, "Base URL of the GitHub REST API (default https://api.github.com, or <webBaseURL>/api/v3 for GitHub Enterprise)")
+	fs.BoolVar(&s.Prerelease, "prerelease", s.Prerelease, "Include pre-releases such as release candidates")
+	fs.StringVar(&s.TagPrefix, "tagPrefix", s.TagPrefix, "Analyze the release series of a tagged sub-module (e.g., cmd/builder for cmd/builder/v0.106.1 tags)")
+}
+
+func componentFlags(fs *flag.FlagSet, s *settings) {
+	fs.Var(&s.Components, "components", "Comma-separated list of components (e.g., prometheusreceiver,awss3exporter)")
+	fs.Var(&s.GoModPath, "goModPath", "Comma-separated paths to go.mod files (e.g., /app/go.mod)")
+	fs.Var(&s.DependencyFilter, "dependencyFilter", "Comma-separated filters for dependencies in go.mod (e.g., open-telemetry-contrib)")
+	fs.Var(&s.Aliases, "alias", "Other name of a component in release notes as component=alias1|alias2, repeatable")
+}
+
+func httpFlags(fs *flag.FlagSet, s *settings) {
+	fs.StringVar(&s.CacheDir, "cache-dir", s.CacheDir, "Directory of the persistent HTTP cache")
+	fs.BoolVar(&s.NoCache, "no-cache", s.NoCache, "Disable the persistent HTTP cache")
+	fs.BoolVar(&s.Offline, "offline", s.Offline, "Serve HTTP responses only from the cache, failing on a cache miss")
+	fs.StringVar(&s.Record, "record", s.Record, "Record all HTTP exchanges of the run into the given fixture file")
+	fs.StringVar(&s.Replay, "replay", s.Replay, "Serve all HTTP requests from the given fixture file instead of the network")
+	fs.DurationVar(&s.Timeout, "timeout", s.Timeout, "Timeout of a single HTTP request attempt")
+	fs.IntVar(&s.Retries, "retries", s.Retries, "Number of retries for HTTP requests failing with a connection error, 429 or 5xx")
+	fs.DurationVar(&s.RetryBackoff, "retryBackoff", s.RetryBackoff, "Initial backoff between HTTP retries, doubled on every retry")
+	fs.DurationVar(&s.RetryMaxBackoff, "retryMaxBackoff", s.RetryMaxBackoff, "Maximum backoff between HTTP retries, also caps Retry-After")
+}
+
+// newFlagSet creates the flag set of the subcommand bound to s.
+func (c command) newFlagSet(s *settings, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&s.Config, "config", s.Config, "Path to the YAML config file shared by all subcommands (default "+defaultConfigFile+" when present), flags override its values")
+	fs.StringVar(&s.Profile, "profile", s.Profile, "Name of the profile from the config file to apply")
+	c.flags(fs, s)
+	fs.Usage = func() {
+		fmt.Fprintf(output, "Usage: changes-analyzer %s [flags]\n\n%s.\n\nFlags:\n", c.name, c.description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// defaultConfigFile is loaded from the working directory when no config file is given.
+const defaultConfigFile = ".changes-analyzer.yaml"
+
+// parseSettings parses the arguments of the subcommand. Values from the config file and the selected profile
+// are applied first, so the arguments are parsed a second time to let explicit flags override them.
+func (c command) parseSettings(args []string, output io.Writer) (*settings, *flag.FlagSet, error) {
+	s := defaultSettings()
+	fs := c.newFlagSet(&s, output)
+	if err := fs.Parse(args); err != nil {
+		return nil, fs, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fs, usageError{fmt.Sprintf("unexpected arguments: %s", strings.Join(fs.Args(), " "))}
+	}
+	configPath, profile := s.Config, s.Profile
+	if configPath == "" {
+		if _, err := os.Stat(defaultConfigFile); err == nil {
+			configPath = defaultConfigFile
+		}
+	}
+	if configPath == "" {
+		if profile != "" {
+			return nil, fs, usageError{fmt.Sprintf("profile %q requires a config file", profile)}
+		}
+		return &s, fs, nil
+	}
+
+	s = defaultSettings()
+	if err := loadConfigFile(configPath, profile, &s); err != nil {
+		return nil, fs, err
+	}
+	fs = c.newFlagSet(&s, output)
+	if err := fs.Parse(args); err != nil {
+		return nil, fs, err
+	}
+	s.Config = configPath
+	return &s, fs, nil
+}
+
+// configFile is the layout of the config file. Top-level settings apply to every run,
+// the selected profile is applied on top of them.
+type configFile struct {
+	settings `yaml:",inline"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// loadConfigFile applies the values of the YAML config file and of the named profile to s.
+func loadConfigFile(path, profile string, s *settings) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	config := configFile{settings: *s}
+	if err := decodeStrict(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	*s = config.settings
+	if profile == "" {
+		return nil
+	}
+
+	node, ok := config.Profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(config.Profiles))
+		for name := range config.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("profile %q not found in %s, available profiles: %s", profile, path, strings.Join(names, ", "))
+	}
+	// The node is encoded again so that unknown keys in the profile are reported as well.
+	profileData, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("failed to read profile %q: %v", profile, err)
+	}
+	if err := decodeStrict(profileData, s); err != nil {
+		return fmt.Errorf("failed to parse profile %q in %s: %v", profile, path, err)
+	}
+	return nil
+}
+
+// decodeStrict decodes YAML into v, failing on keys that v does not know.
+func decodeStrict(data []byte, v any) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// findCommand returns the subcommand with the given name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(output io.Writer) {
+	fmt.Fprintf(output, "Usage: changes-analyzer <command> [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(output, "  %-12s %s\n", c.name, c.description)
+	}
+	fmt.Fprintf(output, "\nRun 'changes-analyzer <command> -h' for the flags of a command.\n")
+}
+
+// run executes the CLI and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 1
+	}
+	name := args[0]
+	// Invocations without a subcommand keep working as report, as they did before subcommands existed.
+	if strings.HasPrefix(name, "-") && name != "-h" && name != "-help" && name != "--help" {
+		name = "report"
+	} else {
+		args = args[1:]
+	}
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		printUsage(stdout)
+		return 0
+	}
+	c, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown command %q.\n", name)
+		printUsage(stderr)
+		return 1
+	}
+
+	s, fs, err := c.parseSettings(args, stderr)
+	if err == nil {
+		err = c.run(s, stdout)
+	}
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errCheckFailed):
+		return 2
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		fs.Usage()
+		return 1
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+}
+
+// releaseRange validates and returns the source repositories and the options that select the analyzed releases and changes.
+func (s *settings) releaseRange() ([]githubRepo, analysisOptions, error) {
+	rng, err := s.versionRange()
+	if err != nil {
+		return nil, analysisOptions{}, err
+	}
+	if len(s.Repo) == 0 {
+		return nil, analysisOptions{}, usageError{"repo is required"}
+	}
+	if rng.Chloggen != "" && len(s.Repo) > 1 {
+		return nil, analysisOptions{}, usageError{"chloggen requires a single repo, the repo of the local clone"}
+	}
+	if s.Clone != "" && len(s.Repo) > 1 {
+		return nil, analysisOptions{}, usageError{"clone requires a single repo, the repo of the local clone"}
+	}
+	var repos []githubRepo
+	for _, spec := range s.Repo {
+		repo, err := parseRepo(spec, s.WebBaseURL, s.APIBaseURL)
+		if err != nil {
+			return nil, analysisOptions{}, usageError{err.Error()}
+		}
+		repos = append(repos, repo)
+	}
+	for _, category := range s.Categories {
+		if !slices.Contains(allCategories, category) {
+			return nil, analysisOptions{}, usageError{fmt.Sprintf("unknown category %q", category)}
+		}
+	}
+	if s.Format != formatMarkdown && s.Format != formatJSON {
+		return nil, analysisOptions{}, usageError{fmt.Sprintf("unknown format %q, expected markdown or json", s.Format)}
+	}
+	if s.Audience != audienceUser && s.Audience != audienceAPI && s.Audience != audienceAll {
+		return nil, analysisOptions{}, usageError{fmt.Sprintf("unknown audience %q, expected user, api or all", s.Audience)}
+	}
+	if s.GroupBy != groupByComponent && s.GroupBy != groupByVersion {
+		return nil, analysisOptions{}, usageError{fmt.Sprintf("unknown group-by %q, expected component or version", s.GroupBy)}
+	}
+	opts := analysisOptions{
+		Filter:     releaseFilter{IncludePrereleases: s.Prerelease, TagPrefix: strings.Trim(s.TagPrefix, "/")},
+		Range:      rng,
+		Aliases:    s.aliases(),
+		Categories: s.Categories,
+		Audience:   s.Audience,
+		Format:     s.Format,
+		GroupBy:    s.GroupBy,
+		Encode:     s.Encode,
+		Clone:      s.Clone,
+		Metadata:   s.Metadata,
+		Options:    s.Options,
+		Defaults:   s.Defaults,
+	}
+	if s.API {
+		if opts.APIModules, err = s.apiModules(); err != nil {
+			return nil, analysisOptions{}, err
+		}
+	}
+	if s.Metadata || s.Options || s.Defaults || s.API || len(s.Sources) > 0 {
+		opts.ModCache = s.modCache()
+	}
+	opts.Sources = s.Sources
+	return repos, opts, nil
+}
+
+// apiModules validates and returns the modules whose API is diffed.
+func (s *settings) apiModules() ([]apiModule, error) {
+	if len(s.Modules) == 0 {
+		return nil, usageError{"modules are required"}
+	}
+	var modules []apiModule
+	for _, spec := range s.Modules {
+		module, err := parseAPIModule(spec)
+		if err != nil {
+			return nil, usageError{err.Error()}
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// moduleRange validates the options of the subcommands comparing two versions of the component modules in the module cache,
+// and returns the repositories whose module paths contain the components, and the components.
+func (s *settings) moduleRange() ([]githubRepo, []string, error) {
+	if s.Old == "" || s.New == "" {
+		return nil, nil, usageError{"old and new module versions are required"}
+	}
+	if len(s.Repo) == 0 {
+		return nil, nil, usageError{"repo is required"}
+	}
+	if s.Format != formatMarkdown && s.Format != formatJSON {
+		return nil, nil, usageError{fmt.Sprintf("unknown format %q, expected markdown or json", s.Format)}
+	}
+	var repos []githubRepo
+	for _, spec := range s.Repo {
+		repo, err := parseRepo(spec, s.WebBaseURL, s.APIBaseURL)
+		if err != nil {
+			return nil, nil, usageError{err.Error()}
+		}
+		repos = append(repos, repo)
+	}
+	componentsOfInterest, err := s.componentsOfInterest()
+	if err != nil {
+		return nil, nil, err
+	}
+	return repos, componentsOfInterest, nil
+}
+
+// versionRange validates the options selecting the analyzed releases. Releases are selected either by old and new version,
+// or by a version range expression and publication dates, which can be combined, or are the pending changes of a local clone.
+func (s *settings) versionRange() (versionRange, error) {
+	rng := versionRange{Old: s.Old, New: s.New, Constraints: s.Range}
+	if s.Chloggen != "" {
+		if rng.byReleases() || s.Range != "" || s.Since != "" || s.Until != "" {
+			return versionRange{}, usageError{"chloggen cannot be combined with old, new, range, since or until"}
+		}
+		return versionRange{Chloggen: s.Chloggen}, nil
+	}
+	for _, date := range []struct {
+		flag  string
+		value string
+		dest  *time.Time
+	}{{"since", s.Since, &rng.Since}, {"until", s.Until, &rng.Until}} {
+		if date.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, date.value)
+		if err != nil {
+			return versionRange{}, usageError{fmt.Sprintf("invalid %s date %q, expected YYYY-MM-DD", date.flag, date.value)}
+		}
+		*date.dest = parsed
+	}
+
+	byFilter := rng.Constraints != "" || s.Since != "" || s.Until != ""
+	switch {
+	case rng.byReleases() && byFilter:
+		return versionRange{}, usageError{"old and new cannot be combined with range, since or until"}
+	case !rng.byReleases() && !byFilter:
+		return versionRange{}, usageError{"old tag is required, or a range, since or until to select the releases"}
+	case rng.byReleases() && rng.Old == "":
+		return versionRange{}, usageError{"old tag is required with new tag"}
+	case !rng.Since.IsZero() && !rng.Until.IsZero() && rng.Until.Before(rng.Since):
+		return versionRange{}, usageError{fmt.Sprintf("reversed date range: since %s is after until %s", s.Since, s.Until)}
+	}
+	return rng, nil
+}
+
+// aliases returns the other names of the components in release notes.
+func (s *settings) aliases() map[string][]string {
+	aliases := make(map[string][]string, len(s.Aliases))
+	for component, names := range s.Aliases {
+		aliases[component] = names
+	}
+	return aliases
+}
+
+// modCache returns the Go module cache given in the settings, or the one of go.
+func (s *settings) modCache() string {
+	if s.ModCache != "" {
+		return s.ModCache
+	}
+	return defaultModCache()
+}
+
+// componentsOfInterest returns the components given explicitly, or extracted from all go.mod files with all filters.
+func (s *settings) componentsOfInterest() ([]string, error) {
+	if len(s.Components) > 0 {
+		// Use provided components if available
+		return s.Components, nil
+	}
+	if len(s.GoModPath) == 0 || len(s.DependencyFilter) == 0 {
+		return nil, usageError{"either components or both goModPath and dependencyFilter are required"}
+	}
+	// Or extract components from go.mod
+	var componentsOfInterest []string
+	for _, goModPath := range s.GoModPath {
+		for _, dependencyFilter := range s.DependencyFilter {
+			components, err := getComponentsFromGoMod(goModPath, dependencyFilter)
+			if err != nil {
+				return nil, err
+			}
+			for component := range strings.SplitSeq(components, ",") {
+				if component != "" && !slices.Contains(componentsOfInterest, component) {
+					componentsOfInterest = append(componentsOfInterest, component)
+				}
+			}
+		}
+	}
+	return componentsOfInterest, nil
+}
+
+// setupHTTP configures retries, cache, recording and replaying of HTTP requests.
+// The returned function must be called at the end of the run, it saves the recorded fixture.
+func (s *settings) setupHTTP() (func() error, error) {
+	setRetryPolicy(retryPolicy{
+		Timeout:        s.Timeout,
+		MaxRetries:     s.Retries,
+		InitialBackoff: s.RetryBackoff,
+		MaxBackoff:     s.RetryMaxBackoff,
+	})
+	if s.NoCache && s.Offline {
+		return nil, usageError{"offline and no-cache cannot be used together"}
+	}
+	if s.Record != "" && s.Replay != "" {
+		return nil, usageError{"record and replay cannot be used together"}
+	}
+	// Recording bypasses the cache, an offline recording would silently go to the network
+	if s.Record != "" && s.Offline {
+		return nil, usageError{"offline and record cannot be used together"}
+	}
+
+	finish := func() error { return nil }
+	if s.Record != "" {
+		recorder := newRecordingTransport(client.Transport)
+		client.Transport = recorder
+		finish = func() error { return recorder.save(s.Record) }
+	}
+	if s.Replay != "" {
+		replayer, err := newReplayTransport(s.Replay)
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = replayer
+	}
+	// Recording and replaying must see every exchange, so the cache is bypassed for them.
+	if !s.NoCache && s.Record == "" && s.Replay == "" {
+		cache, err := newHTTPCache(s.CacheDir, s.Offline)
+		if err != nil {
+			return nil, err
+		}
+		responseCache = cache
+	}
+	return finish, nil
+}
+
+// withHTTP runs fn with HTTP configured. The fixture is saved even for a failed run, so that it can be attached to a bug report.
+func (s *settings) withHTTP(fn func() error) error {
+	finish, err := s.setupHTTP()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	if saveErr := finish(); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+	return err
+}
@@ -0,0 +1,324 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestParseSettingsConfigFile(t *testing.T) {
+	config := writeConfig(t, `
+repo: solarwinds/solarwinds-otel-collector-contrib
+old: v0.119.0
+new: v0.121.0
+components:
+  - prometheusreceiver
+  - awss3exporter
+timeout: 5s
+`)
+	c, _ := findCommand("report")
+	s, _, err := c.parseSettings([]string{"--config", config, "--new", "v0.122.0"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseSettings() error = %v, want nil", err)
+	}
+	if strings.Join(s.Repo, ",") != "solarwinds/solarwinds-otel-collector-contrib" || s.Old != "v0.119.0" {
+		t.Errorf("config values were not applied: repo %q, old %q", s.Repo, s.Old)
+	}
+	if s.New != "v0.122.0" {
+		t.Errorf("flag did not override config: new = %q, but we expected %q", s.New, "v0.122.0")
+	}
+	if strings.Join(s.Components, ",") != "prometheusreceiver,awss3exporter" {
+		t.Errorf("components = %v, but we expected the list from the config", s.Components)
+	}
+	if s.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, but we expected 5s", s.Timeout)
+	}
+	if s.Retries != defaultRetryPolicy().MaxRetries {
+		t.Errorf("retries = %d, but we expected the default %d", s.Retries, defaultRetryPolicy().MaxRetries)
+	}
+}
+
+func TestParseSettingsProfile(t *testing.T) {
+	config := writeConfig(t, `
+goModPath: ../../cmd/solarwinds-otel-collector/go.mod
+timeout: 1m
+profiles:
+  contrib:
+    repo: opentelemetry-collector-contrib
+    dependencyFilter: opentelemetry-collector-contrib
+    aliases:
+      prometheusreceiver: [receiver/prometheus]
+    categories: breaking_changes,deprecations
+    format: json
+  solarwinds-contrib:
+    repo: [solarwinds/solarwinds-otel-collector-contrib, opentelemetry-collector-contrib]
+    goModPath: [a/go.mod, b/go.mod]
+`)
+	c, _ := findCommand("report")
+
+	s, _, err := c.parseSettings([]string{"--config", config, "--profile", "contrib", "--format", "markdown"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseSettings() error = %v, want nil", err)
+	}
+	if strings.Join(s.Repo, ",") != "opentelemetry-collector-contrib" || strings.Join(s.DependencyFilter, ",") != "opentelemetry-collector-contrib" {
+		t.Errorf("profile values were not applied: repo %v, dependencyFilter %v", s.Repo, s.DependencyFilter)
+	}
+	if strings.Join(s.GoModPath, ",") != "../../cmd/solarwinds-otel-collector/go.mod" || s.Timeout != time.Minute {
+		t.Errorf("top-level values were not kept: goModPath %v, timeout %v", s.GoModPath, s.Timeout)
+	}
+	if strings.Join(s.Aliases["prometheusreceiver"], ",") != "receiver/prometheus" {
+		t.Errorf("aliases = %v, but we expected receiver/prometheus for prometheusreceiver", s.Aliases)
+	}
+	if strings.Join(s.Categories, ",") != "breaking_changes,deprecations" {
+		t.Errorf("categories = %v, but we expected breaking_changes,deprecations", s.Categories)
+	}
+	if s.Format != formatMarkdown {
+		t.Errorf("flag did not override profile: format = %q, but we expected %q", s.Format, formatMarkdown)
+	}
+
+	s, _, err = c.parseSettings([]string{"--config", config, "--profile", "solarwinds-contrib"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseSettings() error = %v, want nil", err)
+	}
+	if len(s.Repo) != 2 || strings.Join(s.GoModPath, ",") != "a/go.mod,b/go.mod" {
+		t.Errorf("profile lists were not applied: repo %v, goModPath %v", s.Repo, s.GoModPath)
+	}
+
+	_, _, err = c.parseSettings([]string{"--config", config, "--profile", "core"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), `profile "core" not found`) || !strings.Contains(err.Error(), "available profiles: contrib, solarwinds-contrib") {
+		t.Errorf("parseSettings() error = %v, but we expected a missing profile error", err)
+	}
+}
+
+func TestParseSettingsUnknownConfigKey(t *testing.T) {
+	config := writeConfig(t, "repository: opentelemetry-collector\n")
+	c, _ := findCommand("versions")
+	_, _, err := c.parseSettings([]string{"--config", config}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "field repository not found") {
+		t.Errorf("parseSettings() error = %v, but we expected an unknown field error", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	fixture := filepath.Join("testdata", "synthetic-contrib-v0.121.0-v0.122.0.json")
+	datedFixture := filepath.Join("testdata", "synthetic-contrib-v0.121.0-v0.122.0-dated.json")
+	apiFixture := filepath.Join("testdata", "synthetic-contrib-v0.121.0-v0.122.0-api.json")
+	rangeArgs := []string{"--replay", fixture, "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0"}
+	modCache := writeModCache(t, map[string]string{
+		contribModules + "exporter/elasticsearchexporter@v0.121.0/metadata.yaml":             "status:\n  stability:\n    beta: [logs]\n",
+		contribModules + "exporter/elasticsearchexporter@v0.122.0/metadata.yaml":             "status:\n  stability:\n    unmaintained: [logs]\n",
+		contribModules + "exporter/elasticsearchexporter@v0.121.0/go.mod":                    "module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter\n",
+		contribModules + "exporter/elasticsearchexporter@v0.122.0/go.mod":                    "module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter\n",
+		contribModules + "exporter/elasticsearchexporter@v0.121.0/config.go":                 "package elasticsearchexporter\n\ntype Config struct {\n\tIndex string `mapstructure:\"index\"`\n}\n",
+		contribModules + "exporter/elasticsearchexporter@v0.122.0/config.go":                 "package elasticsearchexporter\n\ntype Config struct {\n\tLogsIndex string `mapstructure:\"logs_index\"`\n}\n",
+		contribModules + "pkg/ottl@v0.121.0/functions.go":                                    "package ottl\n\nfunc NewParser(functions map[string]Factory) Parser { return Parser{} }\n",
+		"github.com/solarwinds/solarwinds-otel-collector-contrib/pkg/k8s@v0.122.0/filter.go": "package k8s\n\nimport \"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl\"\n\nvar _ = ottl.NewBoolExprForSpanEvent\n",
+		contribModules + "pkg/ottl@v0.122.0/functions.go":                                    "package ottl\n\nfunc NewParser(functions map[string]Factory, settings Settings) Parser { return Parser{} }\n",
+		contribModules + "pkg/ottl@v0.121.0/parser.go":                                       "package ottl\n\ntype Factory interface{}\n\ntype Parser struct{}\n",
+		contribModules + "pkg/ottl@v0.122.0/parser.go":                                       "package ottl\n\ntype Factory interface{}\n\ntype Parser struct{}\n\ntype Settings struct{}\n",
+	})
+	goMod := filepath.Join(writeClone(t, map[string]string{"go.mod": "module example.com/distribution\n\nrequire github.com/solarwinds/solarwinds-otel-collector-contrib/pkg/k8s v0.122.0\n"}), "go.mod")
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "components",
+			args:       []string{"components", "--components", "prometheusreceiver, awss3exporter"},
+			wantStdout: "prometheusreceiver\nawss3exporter\n",
+		},
+		{
+			name:       "components without source",
+			args:       []string{"components"},
+			wantCode:   1,
+			wantStderr: "Error: either components or both goModPath and dependencyFilter are required\nUsage: changes-analyzer components [flags]",
+		},
+		{
+			name:       "versions",
+			args:       append([]string{"versions"}, rangeArgs...),
+			wantStdout: "0.121.0  v0.121.0\n0.122.0  v0.122.0\n",
+		},
+		{
+			name:       "versions since a date",
+			args:       []string{"versions", "--replay", datedFixture, "--repo", "opentelemetry-collector-contrib", "--since", "2025-03-01"},
+			wantStdout: "0.121.0  v0.121.0\n0.122.0  v0.122.0\n",
+		},
+		{
+			name:       "versions in a range expression",
+			args:       []string{"versions", "--replay", fixture, "--repo", "opentelemetry-collector-contrib", "--range", ">=0.120.0,<0.122.0"},
+			wantStdout: "0.120.1  v0.120.1\n0.121.0  v0.121.0\n",
+		},
+		{
+			name:       "versions up to latest",
+			args:       []string{"versions", "--replay", fixture, "--repo", "opentelemetry-collector-contrib", "--old", "latest-minor"},
+			wantStdout: "0.122.0  v0.122.0\n",
+		},
+		{
+			name:       "reversed versions",
+			args:       []string{"versions", "--replay", fixture, "--repo", "opentelemetry-collector-contrib", "--old", "v0.122.0", "--new", "v0.121.0"},
+			wantCode:   1,
+			wantStderr: "reversed range: old version v0.122.0 (v0.122.0) is newer than new version v0.121.0 (v0.121.0), swap --old and --new",
+		},
+		{
+			name:       "old and date range",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--since", "2025-03-01"},
+			wantCode:   1,
+			wantStderr: "Error: old and new cannot be combined with range, since or until\nUsage: changes-analyzer versions [flags]",
+		},
+		{
+			name:       "chloggen and range",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib", "--chloggen", ".", "--old", "v0.121.0"},
+			wantCode:   1,
+			wantStderr: "Error: chloggen cannot be combined with old, new, range, since or until",
+		},
+		{
+			name:       "chloggen with several repos",
+			args:       []string{"report", "--repo", "opentelemetry-collector,opentelemetry-collector-contrib", "--chloggen", ".", "--components", "otlpreceiver"},
+			wantCode:   1,
+			wantStderr: "Error: chloggen requires a single repo, the repo of the local clone",
+		},
+		{
+			name:       "invalid date",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib", "--since", "03/01/2025"},
+			wantCode:   1,
+			wantStderr: `Error: invalid since date "03/01/2025", expected YYYY-MM-DD`,
+		},
+		{
+			name:       "offline recording",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--offline", "--record", filepath.Join(t.TempDir(), "fixture.json")},
+			wantCode:   1,
+			wantStderr: "Error: offline and record cannot be used together",
+		},
+		{
+			name:       "audience of versions",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--audience", "api"},
+			wantCode:   1,
+			wantStderr: "Error: flag provided but not defined: -audience",
+		},
+		{
+			name:       "no range",
+			args:       []string{"versions", "--repo", "opentelemetry-collector-contrib"},
+			wantCode:   1,
+			wantStderr: "Error: old tag is required, or a range, since or until to select the releases",
+		},
+		{
+			name:       "report without subcommand",
+			args:       append(rangeArgs, "--components", "elasticsearchexporter"),
+			wantStdout: "#### elasticsearchexporter\n",
+		},
+		{
+			name:       "report with metadata",
+			args:       append(append([]string{"report"}, rangeArgs...), "--components", "elasticsearchexporter", "--metadata", "--modcache", modCache),
+			wantStdout: "### Component metadata (v0.121.0 to v0.122.0)\n\n#### elasticsearchexporter\n- **Newly Unmaintained**: logs\n",
+		},
+		{
+			name: "report with options",
+			args: append(append([]string{"report"}, rangeArgs...), "--components", "elasticsearchexporter", "--options", "--modcache", modCache),
+			wantStdout: "### Config changes (v0.121.0 to v0.122.0)\n\n#### elasticsearchexporter\n| Key | Change | Old type | New type |\n| --- | --- | --- | --- |\n" +
+				"| `index` | renamed to `logs_index` | `string` | `string` |\n",
+		},
+		{
+			name: "report with api",
+			args: append(append([]string{"report"}, rangeArgs...), "--components", "elasticsearchexporter", "--api", "--modules", contribModules+"pkg/ottl,go.opentelemetry.io/collector/pdata", "--modcache", modCache),
+			wantStdout: "### API changes (v0.121.0 to v0.122.0)\n\n#### github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl (v0.121.0 to v0.122.0)\n- **Incompatible**:\n" +
+				"  - `NewParser`: changed from func(map[string]Factory) Parser to func(map[string]Factory, Settings) Parser\n",
+		},
+		{
+			name: "report with sources",
+			args: []string{"report", "--replay", apiFixture, "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0",
+				"--components", "elasticsearchexporter", "--sources", goMod, "--modcache", modCache},
+			wantStdout: "### API changes used by our code (v0.121.0 to v0.122.0)\n\n- 0.122.0 (breaking_changes): `pkg/ottl`: Remove `ottl.NewBoolExprForSpanEvent`",
+		},
+		{
+			name:       "check fails on breaking changes",
+			args:       append(append([]string{"check"}, rangeArgs...), "--components", "elasticsearchexporter"),
+			wantCode:   2,
+			wantStdout: "- **Breaking Changes**:\n  - 0.122.0: `elasticsearchexporter`:",
+		},
+		{
+			name:       "check passes without breaking changes",
+			args:       append(append([]string{"check"}, rangeArgs...), "--components", "prometheusreceiver"),
+			wantStdout: "No breaking_changes found for the analyzed components.\n",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"diff"},
+			wantCode:   1,
+			wantStderr: `Error: unknown command "diff".`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalTransport, originalCache := client.Transport, responseCache
+			defer func() { client.Transport, responseCache = originalTransport, originalCache }()
+
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() = %d, but we expected %d, stderr:\n%s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("run() stdout does not contain %q:\n%s", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run() stderr does not contain %q:\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestExtractFeatureGates(t *testing.T) {
+	componentChanges := map[string]categoryToChangesMap{
+		"prometheusreceiver": {
+			enhancements: {
+				{Version: "0.119.0", Text: "prometheusreceiver: Add `receiver.prometheusreceiver.UseCollectorStartTimeFallback` featuregate for the start time metric adjuster (#36364)"},
+				{Version: "0.120.0", Text: "prometheusreceiver: Support exemplars in config.yaml (#36365)"},
+			},
+			breakingChanges: {
+				{Version: "0.121.0", Text: "prometheusreceiver: Promote feature gate receiver.prometheusreceiver.RemoveLegacyResourceAttributes to beta (#36366)"},
+			},
+		},
+	}
+	gates := extractFeatureGates(componentChanges)
+	want := []featureGate{
+		{ID: "receiver.prometheusreceiver.RemoveLegacyResourceAttributes", Component: "prometheusreceiver", Version: "0.121.0", Category: breakingChanges},
+		{ID: "receiver.prometheusreceiver.UseCollectorStartTimeFallback", Component: "prometheusreceiver", Version: "0.119.0", Category: enhancements},
+	}
+	if len(gates) != len(want) {
+		t.Fatalf("extractFeatureGates() = %+v, but we expected %+v", gates, want)
+	}
+	for i := range want {
+		if gates[i] != want[i] {
+			t.Errorf("extractFeatureGates()[%d] = %+v, but we expected %+v", i, gates[i], want[i])
+		}
+	}
+}
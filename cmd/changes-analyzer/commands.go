@@ -0,0 +1,404 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// runReport prints the Markdown report, optionally base64 encoded.
+func runReport(s *settings, out io.Writer) error {
+	repos, opts, err := s.releaseRange()
+	if err != nil {
+		return err
+	}
+	componentsOfInterest, err := s.componentsOfInterest()
+	if err != nil {
+		return err
+	}
+	return s.withHTTP(func() error {
+		var reports []sourceReport
+		for _, repo := range repos {
+			report, err := buildReport(componentsOfInterest, repo, opts)
+			if err != nil {
+				return fmt.Errorf("%s: %v", repo, err)
+			}
+			reports = append(reports, report)
+		}
+		message, err := renderReports(reports, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, message)
+		return nil
+	})
+}
+
+// runComponents prints the components that would be analyzed, one per line.
+func runComponents(s *settings, out io.Writer) error {
+	componentsOfInterest, err := s.componentsOfInterest()
+	if err != nil {
+		return err
+	}
+	for _, component := range componentsOfInterest {
+		fmt.Fprintln(out, component)
+	}
+	return nil
+}
+
+// runVersions prints the releases in the resolved range, one per line in ascending order.
+func runVersions(s *settings, out io.Writer) error {
+	repos, opts, err := s.releaseRange()
+	if err != nil {
+		return err
+	}
+	return s.withHTTP(func() error {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, repo := range repos {
+			resolved, err := resolveReleases(opts.Range, repo, opts.Filter)
+			if err != nil {
+				return err
+			}
+			for _, rel := range resolved.Releases {
+				// The repository column is only needed when the versions of several sources are listed
+				if len(repos) > 1 {
+					fmt.Fprintf(w, "%s\t", repo)
+				}
+				fmt.Fprintf(w, "%s\t%s\n", rel.Version, rel.Tag)
+			}
+		}
+		return w.Flush()
+	})
+}
+
+// featureGate is a feature gate mentioned in a change entry.
+type featureGate struct {
+	ID        string
+	Component string
+	Version   string
+	Category  string
+}
+
+// featureGatePattern matches feature gate IDs, which are dotted identifiers with at least one letter, e.g. receiver.prometheusreceiver.UseCollectorStartTimeFallback.
+var featureGatePattern = regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9_-]*(\.[a-zA-Z0-9_-]+)+\b`)
+
+// extractFeatureGates returns the feature gates mentioned in entries that talk about feature gates.
+func extractFeatureGates(componentChanges map[string]categoryToChangesMap) []featureGate {
+	var gates []featureGate
+	for component, categories := range componentChanges {
+		for category, changes := range categories {
+			for _, change := range changes {
+				lower := strings.ToLower(change.Text)
+				if !strings.Contains(lower, "feature gate") && !strings.Contains(lower, "featuregate") {
+					continue
+				}
+				seen := map[string]bool{}
+				for _, id := range featureGatePattern.FindAllString(change.Text, -1) {
+					if seen[id] {
+						continue
+					}
+					seen[id] = true
+					gates = append(gates, featureGate{ID: id, Component: component, Version: change.Version, Category: category})
+				}
+			}
+		}
+	}
+	sort.Slice(gates, func(i, j int) bool {
+		if gates[i].ID != gates[j].ID {
+			return gates[i].ID < gates[j].ID
+		}
+		return compareVersions(gates[i].Version, gates[j].Version) < 0
+	})
+	return gates
+}
+
+// runGates prints the feature gates mentioned in the changes of the analyzed components.
+func runGates(s *settings, out io.Writer) error {
+	repos, opts, err := s.releaseRange()
+	if err != nil {
+		return err
+	}
+	componentsOfInterest, err := s.componentsOfInterest()
+	if err != nil {
+		return err
+	}
+	return s.withHTTP(func() error {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "GATE\tCOMPONENT\tVERSION\tCATEGORY")
+		for _, repo := range repos {
+			componentChanges, err := getComponentChanges(componentsOfInterest, repo, opts)
+			if err != nil {
+				return err
+			}
+			for _, gate := range extractFeatureGates(componentChanges) {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", gate.ID, gate.Component, gate.Version, gate.Category)
+			}
+		}
+		return w.Flush()
+	})
+}
+
+// runCheck prints the changes in the failOn categories and fails when there are any.
+func runCheck(s *settings, out io.Writer) error {
+	for _, category := range s.FailOn {
+		if !slices.Contains(allCategories, category) {
+			return usageError{fmt.Sprintf("unknown failOn category %q", category)}
+		}
+	}
+	repos, opts, err := s.releaseRange()
+	if err != nil {
+		return err
+	}
+	componentsOfInterest, err := s.componentsOfInterest()
+	if err != nil {
+		return err
+	}
+	return s.withHTTP(func() error {
+		failed := false
+		for _, repo := range repos {
+			componentChanges, err := getComponentChanges(componentsOfInterest, repo, opts)
+			if err != nil {
+				return err
+			}
+			failing := make(map[string]categoryToChangesMap)
+			for component, categories := range componentChanges {
+				for _, category := range s.FailOn {
+					if len(categories[category]) > 0 {
+						if failing[component] == nil {
+							failing[component] = categoryToChangesMap{}
+						}
+						failing[component][category] = categories[category]
+					}
+				}
+			}
+			if len(failing) > 0 {
+				failed = true
+				fmt.Fprintln(out, formatComponentChanges(repo, failing, nil))
+			}
+		}
+		if !failed {
+			fmt.Fprintf(out, "No %s found for the analyzed components.\n", strings.Join(s.FailOn, ", "))
+			return nil
+		}
+		return errCheckFailed
+	})
+}
+
+// runUsages prints the API changelog entries of the analyzed releases whose package paths or identifiers our code uses.
+func runUsages(s *settings, out io.Writer) error {
+	if len(s.Sources) == 0 {
+		return usageError{"sources are required"}
+	}
+	repos, opts, err := s.releaseRange()
+	if err != nil {
+		return err
+	}
+	files := scanGoSources(opts.ModCache, opts.Sources)
+	return s.withHTTP(func() error {
+		type repoUsages struct {
+			Repository string     `json:"repository"`
+			Old        string     `json:"old"`
+			New        string     `json:"new"`
+			Usages     []apiUsage `json:"usages"`
+			repo       githubRepo
+		}
+		var reports []repoUsages
+		for _, repo := range repos {
+			resolved, err := resolveReleases(opts.Range, repo, opts.Filter)
+			if err != nil {
+				return fmt.Errorf("failed to get versions: %v", err)
+			}
+			releaseNotes, err := fetchReleaseChanges(resolved.Releases, repo)
+			if err != nil {
+				return fmt.Errorf("failed to get release notes: %v", err)
+			}
+			reports = append(reports, repoUsages{Repository: repo.String(), Old: resolved.Old, New: resolved.New, Usages: apiUsages(releaseNotes, files, opts), repo: repo})
+		}
+		if s.Format == formatJSON {
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode API usages: %v", err)
+			}
+			fmt.Fprintln(out, string(data))
+			return nil
+		}
+		for _, report := range reports {
+			fmt.Fprintf(out, "## %s\n\n", report.Repository)
+			if len(report.Usages) == 0 {
+				fmt.Fprintf(out, "### API changes used by our code (%s to %s)\n\nNo API changelog entries used by our code.\n\n", report.Old, report.New)
+				continue
+			}
+			fmt.Fprintln(out, formatAPIUsages(report.repo, report.Old, report.New, report.Usages))
+		}
+		return nil
+	})
+}
+
+// runTelemetry prints the telemetry changes of the component modules between two versions and the example configs they affect.
+func runTelemetry(s *settings, out io.Writer) error {
+	repos, componentsOfInterest, err := s.moduleRange()
+	if err != nil {
+		return err
+	}
+
+	references := exampleReferences{}
+	if _, err := os.Stat(s.Examples); err == nil {
+		if references, err = scanExampleConfigs(s.Examples); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: example configs %s not found, references are not reported\n", s.Examples)
+	}
+	opts := analysisOptions{Aliases: s.aliases()}
+	diffs, err := telemetryChanges(s.modCache(), repos, s.Old, s.New, componentsOfInterest, opts, references)
+	if err != nil {
+		return err
+	}
+	if s.Format == formatJSON {
+		if diffs == nil {
+			diffs = []componentTelemetryDiff{}
+		}
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode telemetry changes: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	fmt.Fprint(out, formatTelemetryChanges(s.Old, s.New, diffs))
+	return nil
+}
+
+// runOptions prints the removed, renamed and retyped config keys of the component modules between two versions.
+func runOptions(s *settings, out io.Writer) error {
+	repos, componentsOfInterest, err := s.moduleRange()
+	if err != nil {
+		return err
+	}
+	changes := configChanges(s.modCache(), repos, s.Old, s.New, componentsOfInterest, analysisOptions{Aliases: s.aliases()})
+	if s.Format == formatJSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode config changes: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "### Config changes (%s to %s)\n\nNo config changes found for the analyzed components.\n", s.Old, s.New)
+		return nil
+	}
+	fmt.Fprint(out, formatConfigChanges(s.Old, s.New, changes))
+	return nil
+}
+
+// runDefaults prints the changed default config values of the component modules between two versions.
+func runDefaults(s *settings, out io.Writer) error {
+	repos, componentsOfInterest, err := s.moduleRange()
+	if err != nil {
+		return err
+	}
+	changes := defaultChanges(s.modCache(), repos, s.Old, s.New, componentsOfInterest, analysisOptions{Aliases: s.aliases()})
+	if s.Format == formatJSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode default changes: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "### Default config changes (%s to %s)\n\nNo default changes found for the analyzed components.\n", s.Old, s.New)
+		return nil
+	}
+	fmt.Fprint(out, formatDefaultChanges(s.Old, s.New, changes))
+	return nil
+}
+
+// runAPI prints the Go API changes of the packages of the imported modules between two versions.
+func runAPI(s *settings, out io.Writer) error {
+	if s.Old == "" || s.New == "" {
+		return usageError{"old and new versions are required"}
+	}
+	if s.Format != formatMarkdown && s.Format != formatJSON {
+		return usageError{fmt.Sprintf("unknown format %q, expected markdown or json", s.Format)}
+	}
+	modules, err := s.apiModules()
+	if err != nil {
+		return err
+	}
+	diffs := apiChanges(s.modCache(), modules, s.Old, s.New)
+	if s.Format == formatJSON {
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode API changes: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintf(out, "### API changes (%s to %s)\n\nNo API changes found for the analyzed modules.\n", s.Old, s.New)
+		return nil
+	}
+	fmt.Fprint(out, formatAPIChanges(s.Old, s.New, diffs))
+	return nil
+}
+
+// runLint prints the problems of the example configs and fails when there are any.
+func runLint(s *settings, out io.Writer) error {
+	if len(s.GoModPath) == 0 {
+		return usageError{"goModPath is required to pin the component versions"}
+	}
+	if s.Format != formatMarkdown && s.Format != formatJSON {
+		return usageError{fmt.Sprintf("unknown format %q, expected markdown or json", s.Format)}
+	}
+	modCache := s.modCache()
+	components, err := pinnedComponents(modCache, s.GoModPath)
+	if err != nil {
+		return err
+	}
+	verified, err := readVerifiedComponents(s.Verified)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, components are not checked against it\n", err)
+	}
+	findings, err := newComponentLinter(modCache, components, verified).lintExampleConfigs(s.Examples)
+	if err != nil {
+		return err
+	}
+	if s.Format == formatJSON {
+		if findings == nil {
+			findings = []lintFinding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode lint findings: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		fmt.Fprint(out, formatLintFindings(findings))
+	}
+	if len(findings) > 0 {
+		return errCheckFailed
+	}
+	return nil
+}
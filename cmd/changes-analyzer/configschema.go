@@ -0,0 +1,541 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"go/types"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// goModFile is the part of a go.mod file needed to locate the modules it requires in the module cache.
+type goModFile struct {
+	Module   string
+	Requires []goModRequire
+}
+
+type goModRequire struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// readGoMod reads the module path and the requirements of a go.mod file, in single-line and block form.
+func readGoMod(goModPath string) (goModFile, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return goModFile{}, fmt.Errorf("failed to open go.mod file: %v", err)
+	}
+	defer file.Close()
+	var goMod goModFile
+	inRequire := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inRequire && fields[0] == ")":
+			inRequire = false
+			continue
+		case fields[0] == "module" && len(fields) == 2:
+			goMod.Module = strings.Trim(fields[1], `"`)
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequire = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		case !inRequire:
+			continue
+		}
+		if len(fields) == 2 {
+			goMod.Requires = append(goMod.Requires, goModRequire{
+				Path:     strings.Trim(fields[0], `"`),
+				Version:  fields[1],
+				Indirect: strings.TrimSpace(comment) == "indirect",
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return goModFile{}, fmt.Errorf("error reading go.mod file: %v", err)
+	}
+	return goMod, nil
+}
+
+// moduleCacheDir returns the directory of a module version in the module cache.
+func moduleCacheDir(modCache, modulePath, ver string) string {
+	return filepath.Join(modCache, filepath.FromSlash(escapeModulePath(modulePath))+"@"+ver)
+}
+
+// configKey is a key of a component config, found through the mapstructure tags of the Config struct and its nested structs.
+type configKey struct {
+	// Type is the Go type of the field as written in the source, e.g. configtls.ClientConfig or time.Duration.
+	Type string `json:"type"`
+	// Nested is set when the keys below the key are part of the schema, i.e. the field is a struct or a list of structs
+	// that was found. Keys below maps, interfaces and types of modules missing in the module cache are not known.
+	Nested bool `json:"-"`
+}
+
+// configSchema maps the dotted keys of a component config, e.g. tls.insecure, to their fields.
+// The keys of the elements of a list of structs are written below the key of the list.
+type configSchema map[string]configKey
+
+// wildcardKey stands for the unknown keys of a squashed struct that was not found, e.g. tls.* when the module of
+// an embedded configtls.Config is missing in the module cache.
+const wildcardKey = "*"
+
+// has reports whether the key is part of the schema, or may be part of a squashed struct that was not found.
+func (s configSchema) has(key string) bool {
+	if _, ok := s[key]; ok {
+		return true
+	}
+	parent := ""
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		parent = key[:i+1]
+	}
+	_, ok := s[parent+wildcardKey]
+	return ok
+}
+
+// maxConfigDepth bounds the nesting of config structs, against recursive types.
+const maxConfigDepth = 12
+
+// readConfigSchema parses the Config struct of the root package of a component module with go/ast.
+// Types of other packages are looked up in the module itself and in the modules its go.mod requires.
+func readConfigSchema(modCache, moduleDir string) (configSchema, error) {
+	goMod, err := readGoMod(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	loader := &configLoader{modCache: modCache, moduleDir: moduleDir, goMod: goMod, packages: make(map[string]*goPackage)}
+	var config typeDecl
+	found := false
+	if pkg := loader.load(goMod.Module); pkg != nil {
+		config, found = pkg.types["Config"]
+	}
+	if !found {
+		return nil, fmt.Errorf("no Config type found in %s", moduleDir)
+	}
+	schema := make(configSchema)
+	st, scope := loader.structOf(config.expr, config.scope, 0)
+	if st == nil {
+		return nil, fmt.Errorf("the Config type of %s is not a struct", moduleDir)
+	}
+	loader.addStruct(schema, "", st, scope, 0)
+	return schema, nil
+}
+
+// goPackage holds the type declarations of a parsed package.
+type goPackage struct {
+	name  string
+	types map[string]typeDecl
+}
+
+type typeDecl struct {
+	expr  ast.Expr
+	scope typeScope
+}
+
+// typeScope resolves the identifiers of a type expression: types of its package and packages imported by its file.
+type typeScope struct {
+	pkg     *goPackage
+	imports map[string]string
+	// unnamed lists the import paths without explicit name, whose package names are only known once they are parsed.
+	unnamed []string
+}
+
+type configLoader struct {
+	modCache  string
+	moduleDir string
+	goMod     goModFile
+	// packages caches parsed packages by import path, nil for packages that could not be found.
+	packages map[string]*goPackage
+}
+
+// packageDir returns the directory of an imported package, in the component module or a module it requires.
+func (l *configLoader) packageDir(importPath string) (string, bool) {
+	if importPath == l.goMod.Module || strings.HasPrefix(importPath, l.goMod.Module+"/") {
+		return filepath.Join(l.moduleDir, filepath.FromSlash(strings.TrimPrefix(importPath, l.goMod.Module))), true
+	}
+	var best goModRequire
+	for _, require := range l.goMod.Requires {
+		if (importPath == require.Path || strings.HasPrefix(importPath, require.Path+"/")) && len(require.Path) > len(best.Path) {
+			best = require
+		}
+	}
+	if best.Path == "" {
+		return "", false
+	}
+	return filepath.Join(moduleCacheDir(l.modCache, best.Path, best.Version), filepath.FromSlash(strings.TrimPrefix(importPath, best.Path))), true
+}
+
+// load parses the non-test files of a package, it returns nil when the package is not available.
+func (l *configLoader) load(importPath string) *goPackage {
+	if pkg, ok := l.packages[importPath]; ok {
+		return pkg
+	}
+	l.packages[importPath] = nil
+	dir, ok := l.packageDir(importPath)
+	if !ok {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	pkg := &goPackage{types: make(map[string]typeDecl)}
+	fset := gotoken.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		pkg.name = file.Name.Name
+		scope := typeScope{pkg: pkg, imports: make(map[string]string)}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if spec.Name != nil {
+				scope.imports[spec.Name.Name] = importPath
+			} else {
+				scope.unnamed = append(scope.unnamed, importPath)
+			}
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != gotoken.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				// Files for other platforms may declare the type again, the first declaration wins
+				if _, ok := pkg.types[typeSpec.Name.Name]; !ok {
+					pkg.types[typeSpec.Name.Name] = typeDecl{expr: typeSpec.Type, scope: scope}
+				}
+			}
+		}
+	}
+	if pkg.name == "" {
+		return nil
+	}
+	l.packages[importPath] = pkg
+	return pkg
+}
+
+// majorSuffix matches the major version suffixes of import paths, like /v2 or .v3 of gopkg.in.
+var majorSuffix = regexp.MustCompile(`[/.]v[0-9]+$`)
+
+// importedPackage resolves the package name of a qualified identifier.
+func (l *configLoader) importedPackage(name string, scope typeScope) *goPackage {
+	if importPath, ok := scope.imports[name]; ok {
+		return l.load(importPath)
+	}
+	// Packages are usually named after the last element of their import path, others have to be parsed to know their name
+	for _, guess := range []bool{true, false} {
+		for _, importPath := range scope.unnamed {
+			last := path.Base(majorSuffix.ReplaceAllString(importPath, ""))
+			if guess != (strings.ReplaceAll(last, "-", "") == name) {
+				continue
+			}
+			if pkg := l.load(importPath); pkg != nil && pkg.name == name {
+				return pkg
+			}
+		}
+	}
+	return nil
+}
+
+// structOf returns the struct behind a type expression, following pointers, list elements, named types and optional values.
+func (l *configLoader) structOf(expr ast.Expr, scope typeScope, depth int) (*ast.StructType, typeScope) {
+	if depth > maxConfigDepth {
+		return nil, scope
+	}
+	switch t := expr.(type) {
+	case *ast.StructType:
+		return t, scope
+	case *ast.StarExpr:
+		return l.structOf(t.X, scope, depth+1)
+	case *ast.ParenExpr:
+		return l.structOf(t.X, scope, depth+1)
+	case *ast.ArrayType:
+		return l.structOf(t.Elt, scope, depth+1)
+	case *ast.Ident:
+		if decl, ok := scope.pkg.types[t.Name]; ok {
+			return l.structOf(decl.expr, decl.scope, depth+1)
+		}
+	case *ast.SelectorExpr:
+		qualifier, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil, scope
+		}
+		if pkg := l.importedPackage(qualifier.Name, scope); pkg != nil {
+			if decl, ok := pkg.types[t.Sel.Name]; ok {
+				return l.structOf(decl.expr, decl.scope, depth+1)
+			}
+		}
+	case *ast.IndexExpr:
+		// configoptional.Optional[T] is configured like T
+		if typeName(t.X) == "Optional" {
+			return l.structOf(t.Index, scope, depth+1)
+		}
+	}
+	return nil, scope
+}
+
+// typeName returns the name of a possibly qualified identifier.
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+// addStruct adds the keys of the fields of a struct below the prefix. Embedded structs are squashed, like the
+// collector configs tag them, and unexported fields are not decoded by mapstructure.
+func (l *configLoader) addStruct(schema configSchema, prefix string, st *ast.StructType, scope typeScope, depth int) {
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			if value, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(value).Get("mapstructure")
+			}
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if len(field.Names) == 0 || strings.Contains(","+options+",", ",squash,") {
+			if nested, nestedScope := l.structOf(field.Type, scope, depth+1); nested != nil && depth < maxConfigDepth {
+				l.addStruct(schema, prefix, nested, nestedScope, depth+1)
+			} else {
+				schema[prefix+wildcardKey] = configKey{Type: types.ExprString(field.Type)}
+			}
+			continue
+		}
+		for _, fieldName := range field.Names {
+			if !unicode.IsUpper([]rune(fieldName.Name)[0]) {
+				continue
+			}
+			key := name
+			if key == "" {
+				key = strings.ToLower(fieldName.Name)
+			}
+			l.addField(schema, prefix+key, field.Type, scope, depth)
+		}
+	}
+}
+
+// addField adds a key and, for fields of struct types, the keys below it.
+func (l *configLoader) addField(schema configSchema, key string, expr ast.Expr, scope typeScope, depth int) {
+	schema[key] = configKey{Type: types.ExprString(expr)}
+	nested, nestedScope := l.structOf(expr, scope, depth+1)
+	if nested == nil || depth >= maxConfigDepth {
+		return
+	}
+	size := len(schema)
+	l.addStruct(schema, key+".", nested, nestedScope, depth+1)
+	// Structs without decoded fields, like component.ID, are configured by a scalar
+	schema[key] = configKey{Type: schema[key].Type, Nested: len(schema) > size}
+}
+
+const (
+	configRemoved = "removed"
+	configRenamed = "renamed"
+	configRetyped = "retyped"
+)
+
+// configChange is a removed, renamed or retyped key of a component config.
+type configChange struct {
+	Key  string `json:"key"`
+	Kind string `json:"kind"`
+	// RenamedTo is the new key of a renamed key.
+	RenamedTo string `json:"renamed_to,omitempty"`
+	OldType   string `json:"old_type"`
+	NewType   string `json:"new_type,omitempty"`
+}
+
+// parentKey returns the closest key of the schema that the key is nested in, or "" for top-level keys.
+// Keys may contain dots themselves, like the metrics of metrics.mysql.locks.
+func parentKey(schema configSchema, key string) string {
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		if _, ok := schema[key[:i]]; ok {
+			return key[:i]
+		}
+	}
+	return ""
+}
+
+// diffConfigSchemas compares the config keys of two versions of a component. A removed key is reported as renamed when
+// it is the only removed key of its parent with its type, and a single key of that type was added to the parent. Keys
+// below removed keys are not reported, keys below renamed keys are compared with their counterpart. Structs are not
+// retyped, their keys are compared instead.
+func diffConfigSchemas(old, new configSchema) []configChange {
+	var removed []string
+	for key := range old {
+		if _, ok := new[key]; !ok && !strings.HasSuffix(key, wildcardKey) {
+			removed = append(removed, key)
+		}
+	}
+	added := make(map[string]bool)
+	for key := range new {
+		if _, ok := old[key]; !ok && !strings.HasSuffix(key, wildcardKey) {
+			added[key] = true
+		}
+	}
+	// Parents are handled before the keys below them
+	sort.Slice(removed, func(i, j int) bool {
+		if depthI, depthJ := strings.Count(removed[i], "."), strings.Count(removed[j], "."); depthI != depthJ {
+			return depthI < depthJ
+		}
+		return removed[i] < removed[j]
+	})
+
+	var changes []configChange
+	renamed := make(map[string]string)
+	gone := make(map[string]bool)
+	for _, key := range removed {
+		parent := parentKey(old, key)
+		if gone[parent] {
+			gone[key] = true
+			continue
+		}
+		newParent := parent
+		if to, ok := renamed[parent]; ok {
+			newParent = to
+			if translated := to + strings.TrimPrefix(key, parent); added[translated] {
+				renamed[key] = translated
+				delete(added, translated)
+				continue
+			}
+		}
+		var candidates []string
+		for candidate := range added {
+			if parentKey(new, candidate) == newParent && new[candidate].Type == old[key].Type {
+				candidates = append(candidates, candidate)
+			}
+		}
+		rivals := 0
+		for _, other := range removed {
+			if parentKey(old, other) == parent && old[other].Type == old[key].Type {
+				rivals++
+			}
+		}
+		if len(candidates) == 1 && rivals == 1 {
+			renamed[key] = candidates[0]
+			delete(added, candidates[0])
+			changes = append(changes, configChange{Key: key, Kind: configRenamed, RenamedTo: candidates[0], OldType: old[key].Type, NewType: new[candidates[0]].Type})
+			continue
+		}
+		gone[key] = true
+		changes = append(changes, configChange{Key: key, Kind: configRemoved, OldType: old[key].Type})
+	}
+
+	for key, oldKey := range old {
+		newKey, ok := new[key]
+		if to, isRenamed := renamed[key]; isRenamed {
+			newKey, ok = new[to], true
+		}
+		if !ok || oldKey.Type == newKey.Type || (oldKey.Nested && newKey.Nested) || strings.HasSuffix(key, wildcardKey) {
+			continue
+		}
+		changes = append(changes, configChange{Key: key, Kind: configRetyped, RenamedTo: renamed[key], OldType: oldKey.Type, NewType: newKey.Type})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// configChanges diffs the Config struct of every component between the old and new version of its module in the module cache.
+// Components whose modules or Config structs are missing are skipped with a warning.
+func configChanges(modCache string, repos []githubRepo, oldVersion, newVersion string, componentsOfInterest []string, opts analysisOptions) map[string][]configChange {
+	changes := make(map[string][]configChange)
+	for _, component := range componentsOfInterest {
+		var oldDir, newDir string
+		var err error
+		for _, repo := range repos {
+			if oldDir, newDir, err = componentModuleDirs(modCache, repo, component, oldVersion, newVersion, opts); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping config of %s: %v\n", component, err)
+			continue
+		}
+		oldSchema, err := readConfigSchema(modCache, oldDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping config of %s: %v\n", component, err)
+			continue
+		}
+		newSchema, err := readConfigSchema(modCache, newDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping config of %s: %v\n", component, err)
+			continue
+		}
+		if diff := diffConfigSchemas(oldSchema, newSchema); len(diff) > 0 {
+			changes[component] = diff
+		}
+	}
+	return changes
+}
+
+// formatConfigChanges formats the config changes of the components as a dedicated section of the report, a table per component.
+func formatConfigChanges(oldVersion, newVersion string, changes map[string][]configChange) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### Config changes (%s to %s)\n", oldVersion, newVersion))
+	for _, component := range slices.Sorted(maps.Keys(changes)) {
+		builder.WriteString(fmt.Sprintf("\n#### %s\n| Key | Change | Old type | New type |\n| --- | --- | --- | --- |\n", component))
+		for _, change := range changes[component] {
+			kind := change.Kind
+			if change.Kind == configRenamed || change.RenamedTo != "" {
+				kind = fmt.Sprintf("%s to `%s`", configRenamed, change.RenamedTo)
+				if change.Kind == configRetyped {
+					kind += " and retyped"
+				}
+			}
+			newType := ""
+			if change.NewType != "" {
+				newType = "`" + change.NewType + "`"
+			}
+			builder.WriteString(fmt.Sprintf("| `%s` | %s | `%s` | %s |\n", change.Key, kind, change.OldType, newType))
+		}
+	}
+	return builder.String()
+}
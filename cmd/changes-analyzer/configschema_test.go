@@ -0,0 +1,253 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadGoMod(t *testing.T) {
+	dir := writeModCache(t, map[string]string{"go.mod": `module github.com/solarwinds/solarwinds-otel-collector-releases/verified
+
+go 1.24
+
+require github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver v0.122.0
+
+require (
+	// The batch processor of the core repository
+	go.opentelemetry.io/collector/processor/batchprocessor v0.122.0
+	github.com/go-sql-driver/mysql v1.9.0 // indirect
+)
+
+replace github.com/solarwinds/solarwinds-otel-collector-releases/pkg/version => ../../pkg/version
+`})
+	got, err := readGoMod(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("readGoMod failed: %v", err)
+	}
+	want := goModFile{
+		Module: "github.com/solarwinds/solarwinds-otel-collector-releases/verified",
+		Requires: []goModRequire{
+			{Path: contribModules + "receiver/mysqlreceiver", Version: "v0.122.0"},
+			{Path: "go.opentelemetry.io/collector/processor/batchprocessor", Version: "v0.122.0"},
+			{Path: "github.com/go-sql-driver/mysql", Version: "v1.9.0", Indirect: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readGoMod() returned %+v, but we expected %+v", got, want)
+	}
+}
+
+// mysqlReceiverModule is a component module with a Config of nested, squashed and imported structs, and its dependencies.
+var mysqlReceiverModule = map[string]string{
+	contribModules + "receiver/mysqlreceiver@v0.122.0/go.mod": `module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver
+
+require (
+	go.opentelemetry.io/collector/config/configtls v1.28.0
+	go.opentelemetry.io/collector/scraper/scraperhelper v0.122.0
+	go.opentelemetry.io/collector/config/confignet v1.28.0
+)
+`,
+	contribModules + "receiver/mysqlreceiver@v0.122.0/config.go": `package mysqlreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver/internal/metadata"
+)
+
+type Config struct {
+	scraperhelper.ControllerConfig ` + "`mapstructure:\",squash\"`" + `
+	Username                       string            ` + "`mapstructure:\"username,omitempty\"`" + `
+	Database                       string            ` + "`mapstructure:\"database,omitempty\"`" + `
+	AllowNativePasswords           bool              ` + "`mapstructure:\"allow_native_passwords,omitempty\"`" + `
+	confignet.AddrConfig           ` + "`mapstructure:\",squash\"`" + `
+	TLS                            configtls.ClientConfig ` + "`mapstructure:\"tls,omitempty\"`" + `
+	MetricsBuilderConfig           metadata.MetricsBuilderConfig ` + "`mapstructure:\",squash\"`" + `
+	StatementEvents                StatementEventsConfig ` + "`mapstructure:\"statement_events\"`" + `
+	Queries                        []QueryConfig ` + "`mapstructure:\"queries\"`" + `
+	Labels                         map[string]string ` + "`mapstructure:\"labels\"`" + `
+	Internal                       string ` + "`mapstructure:\"-\"`" + `
+	password                       string
+}
+
+type StatementEventsConfig struct {
+	DigestTextLimit int           ` + "`mapstructure:\"digest_text_limit\"`" + `
+	TimeLimit       time.Duration ` + "`mapstructure:\"time_limit\"`" + `
+}
+
+type QueryConfig struct {
+	SQL string
+}
+`,
+	contribModules + "receiver/mysqlreceiver@v0.122.0/config_test.go":                        "package mysqlreceiver\n\ntype Config struct{}\n",
+	contribModules + "receiver/mysqlreceiver@v0.122.0/internal/metadata/generated_config.go": "package metadata\n\ntype MetricsBuilderConfig struct {\n\tMetrics MetricsConfig `mapstructure:\"metrics\"`\n}\n\ntype MetricsConfig struct {\n\tMysqlLocks MetricConfig `mapstructure:\"mysql.locks\"`\n}\n\ntype MetricConfig struct {\n\tEnabled bool `mapstructure:\"enabled\"`\n}\n",
+	contribModules + "receiver/mysqlreceiver@v0.122.0/metadata.yaml":                         "type: mysql\nstatus:\n  class: receiver\nmetrics:\n  mysql.locks:\n    enabled: true\n",
+	"go.opentelemetry.io/collector/config/configtls@v1.28.0/configtls.go":                    "package configtls\n\ntype ClientConfig struct {\n\tConfig `mapstructure:\",squash\"`\n\tInsecure bool `mapstructure:\"insecure\"`\n}\n\ntype Config struct {\n\tCAFile string `mapstructure:\"ca_file\"`\n}\n",
+	"go.opentelemetry.io/collector/scraper/scraperhelper@v0.122.0/config.go":                 "package scraperhelper\n\nimport \"time\"\n\ntype ControllerConfig struct {\n\tCollectionInterval time.Duration `mapstructure:\"collection_interval\"`\n}\n",
+	// confignet is not downloaded, its squashed keys are missing
+}
+
+func TestReadConfigSchema(t *testing.T) {
+	modCache := writeModCache(t, mysqlReceiverModule)
+	schema, err := readConfigSchema(modCache, filepath.Join(modCache, contribModules+"receiver/mysqlreceiver@v0.122.0"))
+	if err != nil {
+		t.Fatalf("readConfigSchema failed: %v", err)
+	}
+	want := configSchema{
+		"collection_interval":                {Type: "time.Duration"},
+		"username":                           {Type: "string"},
+		"database":                           {Type: "string"},
+		"allow_native_passwords":             {Type: "bool"},
+		"tls":                                {Type: "configtls.ClientConfig", Nested: true},
+		"tls.ca_file":                        {Type: "string"},
+		"tls.insecure":                       {Type: "bool"},
+		"metrics":                            {Type: "MetricsConfig", Nested: true},
+		"metrics.mysql.locks":                {Type: "MetricConfig", Nested: true},
+		"metrics.mysql.locks.enabled":        {Type: "bool"},
+		"statement_events":                   {Type: "StatementEventsConfig", Nested: true},
+		"statement_events.digest_text_limit": {Type: "int"},
+		"statement_events.time_limit":        {Type: "time.Duration"},
+		"queries":                            {Type: "[]QueryConfig", Nested: true},
+		"queries.sql":                        {Type: "string"},
+		"labels":                             {Type: "map[string]string"},
+		"*":                                  {Type: "confignet.AddrConfig"},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("readConfigSchema() returned\n%v\nbut we expected\n%v", schema, want)
+	}
+
+	for key, want := range map[string]bool{"tls.insecure": true, "endpoint": true, "tls.server_name_override": false, "statement_events.limit": false} {
+		if got := schema.has(key); got != want {
+			t.Errorf("has(%q) returned %v, but we expected %v", key, got, want)
+		}
+	}
+
+	if _, err := readConfigSchema(modCache, filepath.Join(modCache, "go.opentelemetry.io/collector/scraper/scraperhelper@v0.122.0")); err == nil ||
+		!strings.Contains(err.Error(), "go.mod") {
+		t.Errorf("readConfigSchema() of a module without go.mod returned %v, but we expected an error", err)
+	}
+}
+
+func TestDiffConfigSchemas(t *testing.T) {
+	old := configSchema{
+		"endpoint":                    {Type: "string"},
+		"transport":                   {Type: "string"},
+		"collection_interval":         {Type: "time.Duration"},
+		"tls":                         {Type: "configtls.ClientConfig", Nested: true},
+		"tls.insecure":                {Type: "bool"},
+		"tls.server_name":             {Type: "string"},
+		"auth":                        {Type: "AuthConfig", Nested: true},
+		"auth.username":               {Type: "string"},
+		"auth.password":               {Type: "configopaque.String"},
+		"statement_events":            {Type: "StatementEventsConfig", Nested: true},
+		"statement_events.limit":      {Type: "int"},
+		"metrics":                     {Type: "MetricsConfig", Nested: true},
+		"metrics.mysql.locks":         {Type: "MetricConfig", Nested: true},
+		"metrics.mysql.locks.enabled": {Type: "bool"},
+		"metrics.mysql.sorts":         {Type: "MetricConfig", Nested: true},
+		"metrics.mysql.sorts.enabled": {Type: "bool"},
+		"*":                           {Type: "confignet.AddrConfig"},
+	}
+	new := configSchema{
+		"endpoint":                         {Type: "string"},
+		"collection_interval":              {Type: "string"},
+		"tls":                              {Type: "configtls.ClientConfigV2", Nested: true},
+		"tls.insecure":                     {Type: "bool"},
+		"tls.server_name_override":         {Type: "string"},
+		"authentication":                   {Type: "AuthConfig", Nested: true},
+		"authentication.username":          {Type: "string"},
+		"authentication.password":          {Type: "string"},
+		"query_sample":                     {Type: "QuerySampleConfig", Nested: true},
+		"metrics":                          {Type: "MetricsConfig", Nested: true},
+		"metrics.mysql.lock.count":         {Type: "MetricConfig", Nested: true},
+		"metrics.mysql.lock.count.enabled": {Type: "bool"},
+		"metrics.mysql.sorts":              {Type: "MetricConfig", Nested: true},
+		"metrics.mysql.sorts.enabled":      {Type: "bool"},
+		"*":                                {Type: "confignet.TCPAddrConfig"},
+	}
+	want := []configChange{
+		{Key: "auth", Kind: configRenamed, RenamedTo: "authentication", OldType: "AuthConfig", NewType: "AuthConfig"},
+		{Key: "auth.password", Kind: configRetyped, RenamedTo: "authentication.password", OldType: "configopaque.String", NewType: "string"},
+		{Key: "collection_interval", Kind: configRetyped, OldType: "time.Duration", NewType: "string"},
+		{Key: "metrics.mysql.locks", Kind: configRenamed, RenamedTo: "metrics.mysql.lock.count", OldType: "MetricConfig", NewType: "MetricConfig"},
+		{Key: "statement_events", Kind: configRemoved, OldType: "StatementEventsConfig"},
+		{Key: "tls.server_name", Kind: configRenamed, RenamedTo: "tls.server_name_override", OldType: "string", NewType: "string"},
+		{Key: "transport", Kind: configRemoved, OldType: "string"},
+	}
+	got := diffConfigSchemas(old, new)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffConfigSchemas() returned\n%+v\nbut we expected\n%+v", got, want)
+	}
+
+	wantMarkdown := "### Config changes (v0.121.0 to v0.122.0)\n\n#### mysqlreceiver\n| Key | Change | Old type | New type |\n| --- | --- | --- | --- |\n" +
+		"| `auth` | renamed to `authentication` | `AuthConfig` | `AuthConfig` |\n" +
+		"| `auth.password` | renamed to `authentication.password` and retyped | `configopaque.String` | `string` |\n" +
+		"| `collection_interval` | retyped | `time.Duration` | `string` |\n" +
+		"| `metrics.mysql.locks` | renamed to `metrics.mysql.lock.count` | `MetricConfig` | `MetricConfig` |\n" +
+		"| `statement_events` | removed | `StatementEventsConfig` |  |\n" +
+		"| `tls.server_name` | renamed to `tls.server_name_override` | `string` | `string` |\n" +
+		"| `transport` | removed | `string` |  |\n"
+	if got := formatConfigChanges("v0.121.0", "v0.122.0", map[string][]configChange{"mysqlreceiver": got}); got != wantMarkdown {
+		t.Errorf("formatConfigChanges() returned %q, but we expected %q", got, wantMarkdown)
+	}
+}
+
+func TestRunOptions(t *testing.T) {
+	files := maps.Clone(mysqlReceiverModule)
+	for name, content := range mysqlReceiverModule {
+		if strings.HasPrefix(name, contribModules) {
+			if strings.HasSuffix(name, "/config.go") {
+				content = strings.Replace(content, `"allow_native_passwords,omitempty"`, `"native_passwords"`, 1)
+				content = strings.Replace(content, "\tpassword ", "\tTransport string `mapstructure:\"transport\"`\n\tpassword ", 1)
+			}
+			files[strings.Replace(name, "@v0.122.0", "@v0.121.0", 1)] = content
+		}
+	}
+	modCache := writeModCache(t, files)
+	args := []string{"options", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0",
+		"--components", "mysqlreceiver,otlpreceiver", "--modcache", modCache}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	want := "### Config changes (v0.121.0 to v0.122.0)\n\n#### mysqlreceiver\n| Key | Change | Old type | New type |\n| --- | --- | --- | --- |\n" +
+		"| `native_passwords` | renamed to `allow_native_passwords` | `bool` | `bool` |\n" +
+		"| `transport` | removed | `string` |  |\n"
+	if stdout.String() != want {
+		t.Errorf("run() printed %q, but we expected %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if code := run(append(args, "--format", "json"), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), `"renamed_to": "allow_native_passwords"`) {
+		t.Errorf("run() = %d, printed unexpected JSON:\n%s", code, stdout.String())
+	}
+
+	stderr.Reset()
+	if code := run([]string{"options", "--repo", "opentelemetry-collector-contrib", "--components", "mysqlreceiver"}, &stdout, &stderr); code != 1 ||
+		!strings.Contains(stderr.String(), "Error: old and new module versions are required") {
+		t.Errorf("run() without versions = %d, stderr:\n%s", code, stderr.String())
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// defaultConfigHarness is the program printing the default config of a component as JSON. The config is marshaled
+// through confmap like the collector does, so the keys are the mapstructure keys users write in their configs.
+const defaultConfigHarness = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	component %q
+)
+
+func main() {
+	conf := confmap.New()
+	if err := conf.Marshal(component.NewFactory().CreateDefaultConfig()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(readable(conf.ToStringMap())); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readable writes durations like they are configured, e.g. 10s instead of nanoseconds.
+func readable(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = readable(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = readable(item)
+		}
+	case time.Duration:
+		return v.String()
+	}
+	return value
+}
+`
+
+// writeDefaultConfigHarness writes the module of the harness requiring the component module in the given version.
+func writeDefaultConfigHarness(dir, modulePath, version string) error {
+	goMod := fmt.Sprintf("module changes-analyzer/defaults\n\ngo 1.22\n\nrequire %s %s\n", modulePath, version)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "main.go"), []byte(fmt.Sprintf(defaultConfigHarness, modulePath)), 0o644)
+}
+
+// loadDefaultConfig builds the harness against the module cache and returns the default config of the component module.
+// The build is offline, all modules of the component have to be downloaded. Tests replace it, as it needs the go tool.
+var loadDefaultConfig = func(modCache, modulePath, version string) (map[string]any, error) {
+	dir, err := os.MkdirTemp("", "changes-analyzer-defaults-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := writeDefaultConfigHarness(dir, modulePath, version); err != nil {
+		return nil, fmt.Errorf("failed to write the default config harness: %v", err)
+	}
+	env := append(os.Environ(), "GOMODCACHE="+modCache, "GOPROXY=off", "GOSUMDB=off", "GOFLAGS=-mod=mod", "GOWORK=off", "GOTOOLCHAIN=local")
+	goCmd := func(args ...string) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command("go", args...)
+		cmd.Dir, cmd.Env, cmd.Stdout, cmd.Stderr = dir, env, &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("go %s failed: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.Bytes(), nil
+	}
+	if _, err := goCmd("mod", "tidy"); err != nil {
+		return nil, err
+	}
+	output, err := goCmd("run", ".")
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]any
+	if err := json.Unmarshal(output, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the default config of %s@%s: %v", modulePath, version, err)
+	}
+	return config, nil
+}
+
+// defaultChange is a config key whose default value changed. Values are JSON, empty when the key has no default in the version.
+type defaultChange struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// flattenDefaults writes the values of a config tree by dotted key, nested mappings are flattened and other values are JSON.
+func flattenDefaults(prefix string, value any, flat map[string]string) {
+	if mapping, ok := value.(map[string]any); ok && (len(mapping) > 0 || prefix == "") {
+		for key, item := range mapping {
+			flattenDefaults(prefix+key+".", item, flat)
+		}
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		data = []byte(fmt.Sprint(value))
+	}
+	flat[strings.TrimSuffix(prefix, ".")] = string(data)
+}
+
+// diffDefaults compares the default configs of two versions of a component, key by key.
+func diffDefaults(old, new map[string]any) []defaultChange {
+	oldValues, newValues := make(map[string]string), make(map[string]string)
+	flattenDefaults("", old, oldValues)
+	flattenDefaults("", new, newValues)
+	keys := slices.Collect(maps.Keys(oldValues))
+	for key := range newValues {
+		if _, ok := oldValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	var changes []defaultChange
+	for _, key := range keys {
+		if oldValues[key] != newValues[key] {
+			changes = append(changes, defaultChange{Key: key, Old: oldValues[key], New: newValues[key]})
+		}
+	}
+	return changes
+}
+
+// defaultChanges diffs the default config of every component between the old and new version of its module in the module cache.
+// Components whose modules are missing or cannot be built are skipped with a warning.
+func defaultChanges(modCache string, repos []githubRepo, oldVersion, newVersion string, componentsOfInterest []string, opts analysisOptions) map[string][]defaultChange {
+	changes := make(map[string][]defaultChange)
+	for _, component := range componentsOfInterest {
+		var oldDir string
+		var err error
+		for _, repo := range repos {
+			if oldDir, _, err = componentModuleDirs(modCache, repo, component, oldVersion, newVersion, opts); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping defaults of %s: %v\n", component, err)
+			continue
+		}
+		goMod, err := readGoMod(filepath.Join(oldDir, "go.mod"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping defaults of %s: %v\n", component, err)
+			continue
+		}
+		oldConfig, err := loadDefaultConfig(modCache, goMod.Module, oldVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping defaults of %s: %v\n", component, err)
+			continue
+		}
+		newConfig, err := loadDefaultConfig(modCache, goMod.Module, newVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping defaults of %s: %v\n", component, err)
+			continue
+		}
+		if diff := diffDefaults(oldConfig, newConfig); len(diff) > 0 {
+			changes[component] = diff
+		}
+	}
+	return changes
+}
+
+// formatDefaultChanges formats the default changes of the components as a dedicated section of the report, a table per component.
+func formatDefaultChanges(oldVersion, newVersion string, changes map[string][]defaultChange) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### Default config changes (%s to %s)\n", oldVersion, newVersion))
+	for _, component := range slices.Sorted(maps.Keys(changes)) {
+		builder.WriteString(fmt.Sprintf("\n#### %s\n| Key | Old default | New default |\n| --- | --- | --- |\n", component))
+		for _, change := range changes[component] {
+			builder.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", change.Key, defaultCell(change.Old), defaultCell(change.New)))
+		}
+	}
+	return builder.String()
+}
+
+// defaultCell writes a default value as code, escaping the pipes of the table. Missing defaults stay empty.
+func defaultCell(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(value, "|", `\|`) + "`"
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"go/parser"
+	gotoken "go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffDefaults(t *testing.T) {
+	old := map[string]any{
+		"timeout":         "200ms",
+		"send_batch_size": float64(8192),
+		"metadata_keys":   []any{},
+		"tls":             map[string]any{"insecure": false, "ca_file": ""},
+		"metrics": map[string]any{
+			"mysql.locks": map[string]any{"enabled": true},
+			"mysql.sorts": map[string]any{"enabled": true},
+		},
+		"headers": map[string]any{},
+	}
+	new := map[string]any{
+		"timeout":         "1s",
+		"send_batch_size": float64(8192),
+		"metadata_keys":   []any{"tenant"},
+		"tls":             map[string]any{"insecure": false, "ca_file": "", "min_version": "1.2"},
+		"metrics": map[string]any{
+			"mysql.locks": map[string]any{"enabled": true},
+			"mysql.sorts": map[string]any{"enabled": false},
+		},
+		"headers": nil,
+	}
+	want := []defaultChange{
+		{Key: "headers", Old: "{}", New: "null"},
+		{Key: "metadata_keys", Old: "[]", New: `["tenant"]`},
+		{Key: "metrics.mysql.sorts.enabled", Old: "true", New: "false"},
+		{Key: "timeout", Old: `"200ms"`, New: `"1s"`},
+		{Key: "tls.min_version", New: `"1.2"`},
+	}
+	got := diffDefaults(old, new)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffDefaults() returned\n%+v\nbut we expected\n%+v", got, want)
+	}
+	if got := diffDefaults(map[string]any{}, map[string]any{}); got != nil {
+		t.Errorf("diffDefaults() of empty configs returned %+v, but we expected nothing", got)
+	}
+
+	wantMarkdown := "### Default config changes (v0.121.0 to v0.122.0)\n\n#### batchprocessor\n| Key | Old default | New default |\n| --- | --- | --- |\n" +
+		"| `headers` | `{}` | `null` |\n" +
+		"| `metadata_keys` | `[]` | `[\"tenant\"]` |\n" +
+		"| `metrics.mysql.sorts.enabled` | `true` | `false` |\n" +
+		"| `timeout` | `\"200ms\"` | `\"1s\"` |\n" +
+		"| `tls.min_version` |  | `\"1.2\"` |\n"
+	if got := formatDefaultChanges("v0.121.0", "v0.122.0", map[string][]defaultChange{"batchprocessor": got}); got != wantMarkdown {
+		t.Errorf("formatDefaultChanges() returned %q, but we expected %q", got, wantMarkdown)
+	}
+}
+
+func TestWriteDefaultConfigHarness(t *testing.T) {
+	dir := t.TempDir()
+	if err := writeDefaultConfigHarness(dir, "go.opentelemetry.io/collector/processor/batchprocessor", "v0.122.0"); err != nil {
+		t.Fatalf("writeDefaultConfigHarness failed: %v", err)
+	}
+	goMod, err := readGoMod(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("readGoMod failed: %v", err)
+	}
+	wantRequires := []goModRequire{{Path: "go.opentelemetry.io/collector/processor/batchprocessor", Version: "v0.122.0"}}
+	if !reflect.DeepEqual(goMod.Requires, wantRequires) {
+		t.Errorf("the harness requires %+v, but we expected %+v", goMod.Requires, wantRequires)
+	}
+	file, err := parser.ParseFile(gotoken.NewFileSet(), filepath.Join(dir, "main.go"), nil, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("the harness does not parse: %v", err)
+	}
+	var imports []string
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	if !strings.Contains(strings.Join(imports, " "), `"go.opentelemetry.io/collector/processor/batchprocessor"`) {
+		t.Errorf("the harness imports %v, but not the component", imports)
+	}
+}
+
+func TestRunDefaults(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		"go.opentelemetry.io/collector/processor/batchprocessor@v0.121.0/go.mod": "module go.opentelemetry.io/collector/processor/batchprocessor\n",
+		"go.opentelemetry.io/collector/processor/batchprocessor@v0.122.0/go.mod": "module go.opentelemetry.io/collector/processor/batchprocessor\n",
+		"go.opentelemetry.io/collector/receiver/otlpreceiver@v0.121.0/go.mod":    "module go.opentelemetry.io/collector/receiver/otlpreceiver\n",
+		"go.opentelemetry.io/collector/receiver/otlpreceiver@v0.122.0/go.mod":    "module go.opentelemetry.io/collector/receiver/otlpreceiver\n",
+	})
+	originalLoad := loadDefaultConfig
+	defer func() { loadDefaultConfig = originalLoad }()
+	var loaded []string
+	loadDefaultConfig = func(cache, modulePath, version string) (map[string]any, error) {
+		if cache != modCache {
+			return nil, fmt.Errorf("unexpected module cache %s", cache)
+		}
+		loaded = append(loaded, modulePath+"@"+version)
+		if strings.HasSuffix(modulePath, "otlpreceiver") {
+			return map[string]any{"protocols": map[string]any{}}, nil
+		}
+		if version == "v0.121.0" {
+			return map[string]any{"timeout": "200ms", "send_batch_size": float64(8192)}, nil
+		}
+		return map[string]any{"timeout": "1s", "send_batch_size": float64(8192)}, nil
+	}
+	args := []string{"defaults", "--repo", "opentelemetry-collector", "--old", "v0.121.0", "--new", "v0.122.0",
+		"--components", "batchprocessor,otlpreceiver,debugexporter", "--modcache", modCache}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	want := "### Default config changes (v0.121.0 to v0.122.0)\n\n#### batchprocessor\n| Key | Old default | New default |\n| --- | --- | --- |\n" +
+		"| `timeout` | `\"200ms\"` | `\"1s\"` |\n"
+	if stdout.String() != want {
+		t.Errorf("run() printed %q, but we expected %q", stdout.String(), want)
+	}
+	wantLoaded := []string{
+		"go.opentelemetry.io/collector/processor/batchprocessor@v0.121.0", "go.opentelemetry.io/collector/processor/batchprocessor@v0.122.0",
+		"go.opentelemetry.io/collector/receiver/otlpreceiver@v0.121.0", "go.opentelemetry.io/collector/receiver/otlpreceiver@v0.122.0",
+	}
+	if !reflect.DeepEqual(loaded, wantLoaded) {
+		t.Errorf("run() built %v, but we expected %v", loaded, wantLoaded)
+	}
+
+	stdout.Reset()
+	if code := run(append(args, "--format", "json"), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), `"new": "\"1s\""`) {
+		t.Errorf("run() = %d, printed unexpected JSON:\n%s", code, stdout.String())
+	}
+
+	// Without the go tool or the modules, the components are skipped
+	loadDefaultConfig = func(string, string, string) (map[string]any, error) {
+		return nil, os.ErrNotExist
+	}
+	stdout.Reset()
+	if code := run(args, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No default changes found for the analyzed components.") {
+		t.Errorf("run() without builds = %d, printed %q", code, stdout.String())
+	}
+}
+
+// writeModuleDownloads writes the modules of testdata/defaults, laid out as module@version directories, to the download
+// cache of a new module cache, like go mod download does. Module paths must be lower case, they are not escaped.
+func writeModuleDownloads(t *testing.T) string {
+	t.Helper()
+	modCache := t.TempDir()
+	// The go tool writes read-only files to the module cache, which have to be writable to be removed
+	t.Cleanup(func() {
+		_ = filepath.WalkDir(modCache, func(path string, d fs.DirEntry, err error) error {
+			if err == nil {
+				_ = os.Chmod(path, 0o755)
+			}
+			return nil
+		})
+	})
+	root := filepath.Join("testdata", "defaults")
+	var modules []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && strings.Contains(d.Name(), "@") {
+			modules = append(modules, path)
+			return filepath.SkipDir
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range modules {
+		rel, _ := filepath.Rel(root, dir)
+		modulePath, version, _ := strings.Cut(filepath.ToSlash(rel), "@")
+		downloads := filepath.Join(modCache, "cache", "download", filepath.FromSlash(modulePath), "@v")
+		if err := os.MkdirAll(downloads, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		archive, err := os.Create(filepath.Join(downloads, version+".zip"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writer := zip.NewWriter(archive)
+		err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			name, _ := filepath.Rel(dir, path)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			entry, err := writer.Create(modulePath + "@" + version + "/" + filepath.ToSlash(name))
+			if err != nil {
+				return err
+			}
+			_, err = entry.Write(data)
+			return err
+		})
+		if err == nil {
+			err = writer.Close()
+		}
+		if err == nil {
+			err = archive.Close()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]string{
+			version + ".mod":  string(goMod),
+			version + ".info": fmt.Sprintf(`{"Version":%q}`, version),
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(downloads, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return modCache
+}
+
+func TestLoadDefaultConfigBuildsHarness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the harness runs the go tool")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is not available")
+	}
+	modCache := writeModuleDownloads(t)
+
+	old, err := loadDefaultConfig(modCache, "example.com/fakereceiver", "v0.1.0")
+	if err != nil {
+		t.Fatalf("loadDefaultConfig() of v0.1.0 failed: %v", err)
+	}
+	wantOld := map[string]any{"endpoint": "localhost:4317", "timeout": "5s", "tls": map[string]any{"insecure": false}}
+	if !reflect.DeepEqual(old, wantOld) {
+		t.Errorf("loadDefaultConfig() of v0.1.0 returned %v, but we expected %v", old, wantOld)
+	}
+	new, err := loadDefaultConfig(modCache, "example.com/fakereceiver", "v0.2.0")
+	if err != nil {
+		t.Fatalf("loadDefaultConfig() of v0.2.0 failed: %v", err)
+	}
+	wantChanges := []defaultChange{
+		{Key: "timeout", Old: `"5s"`, New: `"10s"`},
+		{Key: "tls.insecure", Old: "false", New: "true"},
+	}
+	if got := diffDefaults(old, new); !reflect.DeepEqual(got, wantChanges) {
+		t.Errorf("diffDefaults() of the built configs returned %+v, but we expected %+v", got, wantChanges)
+	}
+
+	// The harness fails when the config cannot be marshaled, and the build fails for modules missing in the module cache
+	if _, err := loadDefaultConfig(modCache, "example.com/fakereceiver", "v0.3.0"); err == nil || !strings.Contains(err.Error(), "go run . failed") || !strings.Contains(err.Error(), "cannot marshal a config") {
+		t.Errorf("loadDefaultConfig() of v0.3.0 error = %v, but we expected the harness to fail", err)
+	}
+	if _, err := loadDefaultConfig(modCache, "example.com/fakereceiver", "v0.9.0"); err == nil || !strings.Contains(err.Error(), "go mod tidy failed") {
+		t.Errorf("loadDefaultConfig() of a missing version error = %v, but we expected the build to fail", err)
+	}
+}
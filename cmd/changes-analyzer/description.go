@@ -0,0 +1,236 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// tokenKind classifies the pieces of a change description.
+type tokenKind int
+
+const (
+	// tokenText is text without special meaning, e.g. spaces and punctuation.
+	tokenText tokenKind = iota
+	// tokenWord is a run of identifier characters, a candidate for a code span.
+	tokenWord
+	// tokenCode is an existing code span.
+	tokenCode
+	// tokenLink is a markdown link, an autolink or a bare URL.
+	tokenLink
+	// tokenReference is a reference to a PR or issue, e.g. #123 or owner/repo#123.
+	tokenReference
+	// tokenMention is a mention of a user or team, e.g. @user or @org/team.
+	tokenMention
+)
+
+type token struct {
+	Kind tokenKind
+	Text string
+}
+
+// tokenizeDescription splits a description into tokens. Concatenating the tokens gives the description back.
+func tokenizeDescription(desc string) []token {
+	var tokens []token
+	text := func(s string) {
+		if s == "" {
+			return
+		}
+		// Adjacent text is merged, which keeps the token list short and easy to compare
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == tokenText {
+			tokens[n-1].Text += s
+			return
+		}
+		tokens = append(tokens, token{Kind: tokenText, Text: s})
+	}
+
+	for i := 0; i < len(desc); {
+		rest := desc[i:]
+		atBoundary := i == 0 || !isWordChar(desc[i-1])
+		if n := codeSpanLength(rest); n > 0 {
+			tokens = append(tokens, token{Kind: tokenCode, Text: rest[:n]})
+			i += n
+			continue
+		}
+		if n := markdownLinkLength(rest); n > 0 {
+			tokens = append(tokens, token{Kind: tokenLink, Text: rest[:n]})
+			i += n
+			continue
+		}
+		if n := urlLength(rest); n > 0 && atBoundary {
+			tokens = append(tokens, token{Kind: tokenLink, Text: rest[:n]})
+			i += n
+			continue
+		}
+		if match := referencePrefixPattern.FindString(rest); match != "" && atBoundary {
+			tokens = append(tokens, token{Kind: tokenReference, Text: match})
+			i += len(match)
+			continue
+		}
+		// An @ within a word, like in an email address, is not a mention
+		if match := mentionPrefixPattern.FindString(rest); match != "" && (i == 0 || !isWordChar(desc[i-1]) && desc[i-1] != '@') {
+			tokens = append(tokens, token{Kind: tokenMention, Text: match})
+			i += len(match)
+			continue
+		}
+		// Email addresses are plain text, their domain is not code
+		if match := emailPrefixPattern.FindString(rest); match != "" && atBoundary {
+			text(match)
+			i += len(match)
+			continue
+		}
+		if isWordChar(desc[i]) {
+			end := i
+			for end < len(desc) && isWordChar(desc[end]) {
+				end++
+			}
+			// Punctuation around a word, like the colon after a component name or a full stop, is not part of it
+			word := desc[i:end]
+			trimmed := strings.TrimLeft(word, wordPunctuation)
+			text(word[:len(word)-len(trimmed)])
+			core := strings.TrimRight(trimmed, wordPunctuation)
+			if core != "" {
+				tokens = append(tokens, token{Kind: tokenWord, Text: core})
+			}
+			text(trimmed[len(core):])
+			i = end
+			continue
+		}
+		text(desc[i : i+1])
+		i++
+	}
+	return tokens
+}
+
+// wordPunctuation are word characters that only connect the parts of a word, so they are trimmed from its ends.
+const wordPunctuation = ".:-/"
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_.:-/", c) >= 0
+}
+
+// codeSpanLength returns the length of the code span at the start of s, or 0.
+// A code span is closed by a backtick run of the same length as the one that opened it.
+func codeSpanLength(s string) int {
+	fence := len(s) - len(strings.TrimLeft(s, "`"))
+	if fence == 0 {
+		return 0
+	}
+	for i := fence; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		run := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+		if run == fence {
+			return i + run
+		}
+		i += run
+	}
+	return 0
+}
+
+// markdownLinkPattern matches a markdown link, [text](url), or an autolink, <https://...>.
+var markdownLinkPattern = regexp.MustCompile(`^(\[[^\]\n]*\]\([^)\s]*\)|<https?://[^>\s]+>)`)
+
+func markdownLinkLength(s string) int {
+	return len(markdownLinkPattern.FindString(s))
+}
+
+var urlPattern = regexp.MustCompile(`^https?://[^\s<>]+`)
+
+// urlLength returns the length of the bare URL at the start of s, or 0.
+// Trailing punctuation belongs to the sentence, as does a closing parenthesis without an opening one in the URL.
+func urlLength(s string) int {
+	url := urlPattern.FindString(s)
+	for url != "" {
+		trimmed := strings.TrimRight(url, ".,;:!?'\"")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == url {
+			break
+		}
+		url = trimmed
+	}
+	return len(url)
+}
+
+// referencePrefixPattern matches a PR or issue reference at the start of the text, optionally to another repository.
+var referencePrefixPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+)?#\d+\b`)
+
+// mentionPrefixPattern matches a user or team mention at the start of the text.
+var mentionPrefixPattern = regexp.MustCompile(`^@[A-Za-z0-9][A-Za-z0-9-]*(/[A-Za-z0-9][A-Za-z0-9_.-]*[A-Za-z0-9_])?`)
+
+// emailPrefixPattern matches an email address at the start of the text.
+var emailPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_.+-]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+`)
+
+// prPattern matches # followed by digits
+var prPattern = regexp.MustCompile(`#(\d+)`)
+
+// abbreviations are dotted words of plain English, which are never code. They are compared in lower case, without the final dot.
+var abbreviations = map[string]bool{
+	"e.g": true, "i.e": true, "a.k.a": true, "etc": true, "vs": true, "cf": true, "n.b": true, "approx": true,
+}
+
+// semverPattern matches versions, e.g. 0.122.0, v1.2 or v0.123.0-rc.1.
+var semverPattern = regexp.MustCompile(`^v?\d+(\.\d+)+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// dottedPattern matches dotted identifiers with at least one letter, e.g. feature gates like
+// receiver.prometheusreceiver.UseCollectorStartTimeFallback, config keys like sending_queue.enabled or metric names like system.cpu.time.
+var dottedPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)+$`)
+
+// configKeyPattern matches keys of the confmap path syntax, e.g. mapping::mode.
+var configKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+(::[A-Za-z0-9_.-]+)+$`)
+
+// metricNamePattern matches snake case metric names with at least two separators, e.g. otelcol_exporter_sent_spans.
+// Words with a single underscore are too often plain text to be formatted.
+var metricNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+){2,}$`)
+
+// modulePathPattern matches Go module and package paths, e.g. go.opentelemetry.io/collector/pdata.
+var modulePathPattern = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+(/[A-Za-z0-9_.-]+)+$`)
+
+// isCodeLike reports whether a word of a description should be formatted as code.
+func isCodeLike(word string) bool {
+	if abbreviations[strings.ToLower(word)] || semverPattern.MatchString(word) {
+		return false
+	}
+	if !strings.ContainsAny(word, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return false
+	}
+	return dottedPattern.MatchString(word) ||
+		configKeyPattern.MatchString(word) ||
+		metricNamePattern.MatchString(word) ||
+		modulePathPattern.MatchString(word)
+}
+
+// formatDescription formats a change description by wrapping identifiers, such as feature gates, config keys and metric names, in backticks.
+// Existing code spans, links, URLs, versions and abbreviations are left as they are.
+func formatDescription(desc string) string {
+	var builder strings.Builder
+	for _, t := range tokenizeDescription(desc) {
+		builder.WriteString(formatWord(t))
+	}
+	return builder.String()
+}
+
+// formatWord wraps code-like words in backticks and returns other tokens as they are.
+func formatWord(t token) string {
+	if t.Kind == tokenWord && isCodeLike(t.Text) {
+		return "`" + t.Text + "`"
+	}
+	return t.Text
+}
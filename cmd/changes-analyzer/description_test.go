@@ -0,0 +1,108 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		// Formatted as code
+		{"feature gate", "Enable receiver.prometheusreceiver.UseCollectorStartTimeFallback featuregate", "Enable `receiver.prometheusreceiver.UseCollectorStartTimeFallback` featuregate"},
+		{"dotted config key", "Set sending_queue.enabled to true", "Set `sending_queue.enabled` to true"},
+		{"confmap config key", "Deprecate mapping::mode config option", "Deprecate `mapping::mode` config option"},
+		{"dotted metric name", "Add system.cpu.time metric.", "Add `system.cpu.time` metric."},
+		{"snake case metric name", "Add otelcol_exporter_sent_spans metric", "Add `otelcol_exporter_sent_spans` metric"},
+		{"module path", "Move to go.opentelemetry.io/collector/pdata, finally", "Move to `go.opentelemetry.io/collector/pdata`, finally"},
+		{"file name in parentheses", "Read settings (config.yaml)", "Read settings (`config.yaml`)"},
+		{"word followed by colon", "Renamed k8s.pod.uid: use the new name", "Renamed `k8s.pod.uid`: use the new name"},
+
+		// Left as they are
+		{"plain text", "elasticsearchexporter: Fix crash", "elasticsearchexporter: Fix crash"},
+		{"single underscore", "Handle the non_empty case", "Handle the non_empty case"},
+		{"component path", "pkg/ottl: Add function", "pkg/ottl: Add function"},
+		{"version", "Upgrade to 0.122.0 and v1.28.0", "Upgrade to 0.122.0 and v1.28.0"},
+		{"pre-release version", "Fixed in v0.123.0-rc.1.", "Fixed in v0.123.0-rc.1."},
+		{"number", "Raise the limit to 1.5 times", "Raise the limit to 1.5 times"},
+		{"abbreviations", "Some options, e.g. timeouts, i.e. all of them, etc.", "Some options, e.g. timeouts, i.e. all of them, etc."},
+		{"sentence end", "It works. Really.", "It works. Really."},
+		{"existing code span", "Use `a.b.c` instead", "Use `a.b.c` instead"},
+		{"existing double backtick code span", "Use ``a`b.c`` instead", "Use ``a`b.c`` instead"},
+		{"unclosed backtick", "Use ` a.b", "Use ` `a.b`"},
+		{"bare URL", "See https://opentelemetry.io/docs/collector.html.", "See https://opentelemetry.io/docs/collector.html."},
+		{"URL in parentheses", "(see https://github.com/open-telemetry/opentelemetry-collector)", "(see https://github.com/open-telemetry/opentelemetry-collector)"},
+		{"markdown link", "Read [config.yaml docs](https://example.com/config.yaml)", "Read [config.yaml docs](https://example.com/config.yaml)"},
+		{"autolink", "Read <https://example.com/a.b>", "Read <https://example.com/a.b>"},
+		{"references", "Fixed (#123, #456)", "Fixed (#123, #456)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDescription(tt.desc); got != tt.want {
+				t.Errorf("formatDescription(%q) returned %q, but we expected %q", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeDescription(t *testing.T) {
+	desc := "`exp`: Use a.b (#12), see [docs](https://x.io) or https://x.io/a."
+	want := []token{
+		{tokenCode, "`exp`"},
+		{tokenText, ": "},
+		{tokenWord, "Use"},
+		{tokenText, " "},
+		{tokenWord, "a.b"},
+		{tokenText, " ("},
+		{tokenReference, "#12"},
+		{tokenText, "), "},
+		{tokenWord, "see"},
+		{tokenText, " "},
+		{tokenLink, "[docs](https://x.io)"},
+		{tokenText, " "},
+		{tokenWord, "or"},
+		{tokenText, " "},
+		{tokenLink, "https://x.io/a"},
+		{tokenText, "."},
+	}
+	got := tokenizeDescription(desc)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenizeDescription(%q) returned %v, but we expected %v", desc, got, want)
+	}
+
+	var joined strings.Builder
+	for _, tok := range got {
+		joined.WriteString(tok.Text)
+	}
+	if joined.String() != desc {
+		t.Errorf("tokens joined to %q, but we expected the original description %q", joined.String(), desc)
+	}
+}
+
+func TestFormatEntryText(t *testing.T) {
+	repo := mustParseRepo(t, "opentelemetry-collector")
+	// Code spans keep their content, while mentions and HTML of the text are sanitized
+	text := "`otlpreceiver`: Set a.b <b>now</b> @someone ([#1](https://example.com/#2) #3, `#4`, `@timestamp <br>`)"
+	want := "`otlpreceiver`: Set `a.b` &lt;b>now&lt;/b> `@someone` ([#1](https://example.com/#2) [#3](https://github.com/open-telemetry/opentelemetry-collector/pull/3), `#4`, `@timestamp <br>`)"
+	if got := formatEntryText(repo, text); got != want {
+		t.Errorf("formatEntryText() returned %q, but we expected %q", got, want)
+	}
+}
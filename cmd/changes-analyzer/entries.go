@@ -0,0 +1,183 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// prNumbers returns the sorted, unique PR numbers referenced by the text, without the '#'.
+func prNumbers(text string) []string {
+	var numbers []string
+	for _, match := range prPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(numbers, match[1]) {
+			numbers = append(numbers, match[1])
+		}
+	}
+	slices.Sort(numbers)
+	return numbers
+}
+
+var nonWordPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// backportPattern matches the wording of backported entries, e.g. (backport of #38500).
+var backportPattern = regexp.MustCompile(`\bbackport(ed)?( of| from)?\b`)
+
+// normalizeText reduces an entry to lower case words without PR references, so that re-listed entries
+// with different punctuation, spacing, references or backport wording compare equal.
+func normalizeText(text string) string {
+	text = prPattern.ReplaceAllString(strings.ToLower(text), " ")
+	text = backportPattern.ReplaceAllString(text, " ")
+	return strings.TrimSpace(nonWordPattern.ReplaceAllString(text, " "))
+}
+
+// backportOfPattern matches the PR a backported entry originates from, e.g. (#38612) (backport of #38500).
+var backportOfPattern = regexp.MustCompile(`(?i)\bbackport(?:ed)?\s+(?:of|from)\s+#(\d+)`)
+
+// originalPRNumbers returns the PRs an entry is identified by. A backport lists its own PR next to the original one,
+// so only the PRs it is a backport of are kept, like for the original entry.
+func originalPRNumbers(text string) []string {
+	var numbers []string
+	for _, match := range backportOfPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(numbers, match[1]) {
+			numbers = append(numbers, match[1])
+		}
+	}
+	if numbers == nil {
+		return prNumbers(text)
+	}
+	slices.Sort(numbers)
+	return numbers
+}
+
+// dedupeKey identifies an entry across releases by its normalized text and the PRs it references. Different changes
+// often reference a shared tracking issue, so the references alone do not identify an entry.
+// A PR can have entries in both changelogs, so the audience is part of the key. A revert can cite the PR of
+// the change it reverts, so reverts are keyed apart and left to collapseReverts.
+func dedupeKey(entry changeEntry) string {
+	if isRevert(entry) {
+		return entry.Audience + "|revert|pr:" + strings.Join(prNumbers(entry.Text), ",") + "|text:" + normalizeText(entry.Text)
+	}
+	return entry.Audience + "|pr:" + strings.Join(originalPRNumbers(entry.Text), ",") + "|text:" + normalizeText(entry.Text)
+}
+
+// dedupeEntries merges entries repeated across releases, e.g. fixes re-listed in a patch release or backports.
+// The entries must be sorted by version, so that the first version an entry appeared in is kept
+// and the versions that repeated it are recorded in AlsoIn. Entries of the same release are distinct changes
+// and are never merged.
+func dedupeEntries(entries []changeEntry) []changeEntry {
+	var result []changeEntry
+	seen := make(map[string]int)
+	for _, entry := range entries {
+		key := dedupeKey(entry)
+		first, duplicate := seen[key]
+		if !duplicate || entry.Version == result[first].Version {
+			if !duplicate {
+				seen[key] = len(result)
+			}
+			result = append(result, entry)
+			continue
+		}
+		if !slices.Contains(result[first].AlsoIn, entry.Version) {
+			result[first].AlsoIn = append(result[first].AlsoIn, entry.Version)
+		}
+	}
+	return result
+}
+
+// revertPattern matches entries led by a revert, after the component prefix, e.g. `Revert "Drop foo"` or
+// "Reverted the removal of ...". The word following the revert is captured to tell apart incidental wording.
+var revertPattern = regexp.MustCompile("(?i)^(?:`?[A-Za-z0-9_./-]+`?:\\s*)?revert(?:s|ed)?\\b\\s*([\\w#\"]*)")
+
+// revertReferencePattern matches entries naming the change they revert, e.g. "reverts #38361".
+var revertReferencePattern = regexp.MustCompile(`(?i)\brevert(?:s|ed)?\s+(?:#\d+|"|pr\s*#?\d+)`)
+
+// isRevert reports whether the entry reverts an earlier change. Incidental wording, like "reverting to the previous default"
+// or "Revert to the previous default", does not make an entry a revert.
+func isRevert(entry changeEntry) bool {
+	if revertReferencePattern.MatchString(entry.Text) {
+		return true
+	}
+	match := revertPattern.FindStringSubmatch(entry.Text)
+	return match != nil && !slices.Contains([]string{"to", "back"}, strings.ToLower(match[1]))
+}
+
+// categorizedEntry locates an entry within the categories of a component.
+type categorizedEntry struct {
+	category string
+	index    int
+}
+
+// revertedCategories lists the categories whose entries can revert or be reverted. A revert is often listed
+// under bug fixes, while the change it reverts is a breaking change or an enhancement.
+var revertedCategories = []string{breakingChanges, deprecations, enhancements, skippedSection}
+
+// collapseReverts pairs entries with the entries reverting them within the analyzed range, using the PRs they reference,
+// e.g. "Revert #38361" or a revert listed with the PR of the original change. Both entries of a pair are removed
+// from their categories, the original one is moved to the reverted category as a "net: no change" note.
+// Reverts of changes released before the range are kept, as they are net changes of the range.
+func collapseReverts(categories categoryToChangesMap) {
+	var reverts, originals []categorizedEntry
+	for _, category := range revertedCategories {
+		for index, entry := range categories[category] {
+			if isRevert(entry) {
+				reverts = append(reverts, categorizedEntry{category, index})
+			} else {
+				originals = append(originals, categorizedEntry{category, index})
+			}
+		}
+	}
+
+	removed := make(map[categorizedEntry]bool)
+	for _, revert := range reverts {
+		revertEntry := categories[revert.category][revert.index]
+		referenced := prNumbers(revertEntry.Text)
+		for _, original := range originals {
+			if removed[original] {
+				continue
+			}
+			originalEntry := categories[original.category][original.index]
+			if compareVersions(originalEntry.Version, revertEntry.Version) > 0 {
+				continue
+			}
+			if !slices.ContainsFunc(prNumbers(originalEntry.Text), func(pr string) bool { return slices.Contains(referenced, pr) }) {
+				continue
+			}
+			originalEntry.RevertedIn = revertEntry.Version
+			categories[reverted] = append(categories[reverted], originalEntry)
+			removed[original], removed[revert] = true, true
+			break
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	for _, category := range revertedCategories {
+		if _, ok := categories[category]; !ok {
+			continue
+		}
+		var kept []changeEntry
+		for index, entry := range categories[category] {
+			if !removed[categorizedEntry{category, index}] {
+				kept = append(kept, entry)
+			}
+		}
+		categories[category] = kept
+	}
+	sortEntries(categories[reverted])
+}
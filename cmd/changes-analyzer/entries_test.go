@@ -0,0 +1,223 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDedupeEntries(t *testing.T) {
+	entries := []changeEntry{
+		{Version: "0.122.0", Text: "kafkareceiver: Fix consumer group rebalancing (#38500)"},
+		{Version: "0.122.0", Text: "kafkareceiver: Add `tls` settings (#38501)"},
+		{Version: "0.122.1", Text: "kafkareceiver: Fix consumer group rebalancing. (#38500)"},
+		{Version: "0.123.0", Text: "kafkareceiver: fix consumer group rebalancing (backport of #38500)"},
+		{Version: "0.122.1", Text: "kafkareceiver: Document the   retry settings"},
+		{Version: "0.123.0", Text: "kafkareceiver: Document the retry settings."},
+		{Version: "0.123.0", Text: "kafkareceiver: Bump dependencies (#38600)"},
+		{Version: "0.124.0", Text: "kafkareceiver: Bump dependencies (#38700)"},
+	}
+	want := []changeEntry{
+		{Version: "0.122.0", Text: "kafkareceiver: Fix consumer group rebalancing (#38500)", AlsoIn: []string{"0.122.1", "0.123.0"}},
+		{Version: "0.122.0", Text: "kafkareceiver: Add `tls` settings (#38501)"},
+		{Version: "0.122.1", Text: "kafkareceiver: Document the   retry settings", AlsoIn: []string{"0.123.0"}},
+		{Version: "0.123.0", Text: "kafkareceiver: Bump dependencies (#38600)"},
+		{Version: "0.124.0", Text: "kafkareceiver: Bump dependencies (#38700)"},
+	}
+	if got := dedupeEntries(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupeEntries() =\n%+v\nbut we expected\n%+v", got, want)
+	}
+
+	// Different changes referencing a shared tracking issue are kept, in the same and in later releases
+	shared := []changeEntry{
+		{Version: "0.123.0", Text: "pkg/stanza: Add `max_batch_size` setting (#24010)"},
+		{Version: "0.123.0", Text: "pkg/stanza: Flush batches on shutdown (#24010)"},
+		{Version: "0.124.0", Text: "pkg/stanza: Deprecate `max_log_size` (#24010)"},
+		{Version: "0.124.0", Text: "pkg/stanza: Flush batches on shutdown (#24010)"},
+	}
+	wantShared := []changeEntry{
+		{Version: "0.123.0", Text: "pkg/stanza: Add `max_batch_size` setting (#24010)"},
+		{Version: "0.123.0", Text: "pkg/stanza: Flush batches on shutdown (#24010)", AlsoIn: []string{"0.124.0"}},
+		{Version: "0.124.0", Text: "pkg/stanza: Deprecate `max_log_size` (#24010)"},
+	}
+	if got := dedupeEntries(shared); !reflect.DeepEqual(got, wantShared) {
+		t.Errorf("dedupeEntries() of a shared issue =\n%+v\nbut we expected\n%+v", got, wantShared)
+	}
+
+	// A backport lists its own PR next to the PR it is a backport of
+	backports := []changeEntry{
+		{Version: "0.122.0", Text: "kafkareceiver: Fix consumer group rebalancing (#38500)"},
+		{Version: "0.122.1", Text: "kafkareceiver: Fix consumer group rebalancing (#38612) (backport of #38500)"},
+		{Version: "0.122.1", Text: "kafkareceiver: Fix offset commits (#38613) (backport of #38501)"},
+	}
+	wantBackports := []changeEntry{
+		{Version: "0.122.0", Text: "kafkareceiver: Fix consumer group rebalancing (#38500)", AlsoIn: []string{"0.122.1"}},
+		{Version: "0.122.1", Text: "kafkareceiver: Fix offset commits (#38613) (backport of #38501)"},
+	}
+	if got := dedupeEntries(backports); !reflect.DeepEqual(got, wantBackports) {
+		t.Errorf("dedupeEntries() of backports =\n%+v\nbut we expected\n%+v", got, wantBackports)
+	}
+
+	// A release listing the same entry twice lists two changes
+	twice := []changeEntry{
+		{Version: "0.123.0", Text: "pkg/stanza: Fix batching (#24010)"},
+		{Version: "0.123.0", Text: "pkg/stanza: Fix batching (#24010)"},
+	}
+	if got := dedupeEntries(twice); !reflect.DeepEqual(got, twice) {
+		t.Errorf("dedupeEntries() of the same release =\n%+v\nbut we expected\n%+v", got, twice)
+	}
+}
+
+func TestFormatComponentChangesRepeatedEntries(t *testing.T) {
+	componentChanges := map[string]categoryToChangesMap{
+		"kafkareceiver": {
+			enhancements: {{Version: "0.122.0", Text: "kafkareceiver: Fix rebalancing", AlsoIn: []string{"0.122.1"}}},
+		},
+	}
+	got := formatComponentChanges(mustParseRepo(t, "opentelemetry-collector-contrib"), componentChanges, nil)
+	want := "#### kafkareceiver\n- **Enhancements**:\n  - 0.122.0 (also in 0.122.1): kafkareceiver: Fix rebalancing\n"
+	if got != want {
+		t.Errorf("formatComponentChanges() = %q, but we expected %q", got, want)
+	}
+}
+
+func TestChangeEntryJSONProvenance(t *testing.T) {
+	data, err := json.Marshal(changeEntry{Version: "0.122.0", Text: "kafkareceiver: Fix rebalancing", Audience: audienceUser, AlsoIn: []string{"0.122.1"}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"version":"0.122.0","text":"kafkareceiver: Fix rebalancing","audience":"user","also_in":["0.122.1"]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, but we expected %s", data, want)
+	}
+}
+
+func TestIsRevert(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"elasticsearchexporter: Revert \"Drop support for Elasticsearch 7\" (#38000)", true},
+		{"`elasticsearchexporter`: Reverted the removal of `dedot` done in #37000 (#38480)", true},
+		{"Revert #38361", true},
+		{"elasticsearchexporter: Restore dynamic routing, reverts #38361 (#38470)", true},
+		{"kafkareceiver: Stop reverting to the previous default on errors (#38490)", false},
+		{"kafkareceiver: Revert to the previous default encoding (#38491)", false},
+		{"kafkareceiver: Add `revert_on_error` setting (#38492)", false},
+	}
+	for _, tt := range tests {
+		if got := isRevert(changeEntry{Text: tt.text}); got != tt.want {
+			t.Errorf("isRevert(%q) returned %v, but we expected %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCollapseReverts(t *testing.T) {
+	categories := categoryToChangesMap{
+		breakingChanges: {
+			{Version: "0.120.0", Text: "elasticsearchexporter: Drop support for Elasticsearch 7 (#38000)"},
+			{Version: "0.121.0", Text: "elasticsearchexporter: Dynamically route documents by default (#38361)"},
+			{Version: "0.122.0", Text: "elasticsearchexporter: Rename `logs_index` to `logs::index` (#38450)"},
+		},
+		enhancements: {
+			{Version: "0.122.0", Text: "elasticsearchexporter: Revert dynamic routing by default, reverts #38361 (#38470)"},
+			{Version: "0.122.0", Text: "elasticsearchexporter: Reverted the removal of `dedot` done in #37000 (#38480)"},
+			{Version: "0.123.0", Text: "elasticsearchexporter: Revert \"Drop support for Elasticsearch 7\" (#38000)"},
+		},
+	}
+	collapseReverts(categories)
+
+	want := categoryToChangesMap{
+		breakingChanges: {
+			{Version: "0.122.0", Text: "elasticsearchexporter: Rename `logs_index` to `logs::index` (#38450)"},
+		},
+		enhancements: {
+			// #37000 was released before the analyzed range, so its revert is a net change
+			{Version: "0.122.0", Text: "elasticsearchexporter: Reverted the removal of `dedot` done in #37000 (#38480)"},
+		},
+		reverted: {
+			{Version: "0.120.0", Text: "elasticsearchexporter: Drop support for Elasticsearch 7 (#38000)", RevertedIn: "0.123.0"},
+			{Version: "0.121.0", Text: "elasticsearchexporter: Dynamically route documents by default (#38361)", RevertedIn: "0.122.0"},
+		},
+	}
+	if !reflect.DeepEqual(categories, want) {
+		t.Errorf("collapseReverts() =\n%+v\nbut we expected\n%+v", categories, want)
+	}
+
+	got := formatComponentChanges(mustParseRepo(t, "opentelemetry-collector-contrib"), map[string]categoryToChangesMap{"elasticsearchexporter": categories}, nil)
+	wantReverted := "- **Reverted (net: no change)**:\n" +
+		"  - 0.120.0 (reverted in 0.123.0): elasticsearchexporter: Drop support for Elasticsearch 7 ([#38000](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/38000))\n"
+	if !strings.Contains(got, wantReverted) {
+		t.Errorf("formatComponentChanges() does not contain %q:\n%s", wantReverted, got)
+	}
+}
+
+func TestFilterComponentChangesRevertCitingOriginal(t *testing.T) {
+	releaseNotes := map[string]map[string][]changeEntry{
+		"0.121.0": {breakingChanges: {{Text: "elasticsearchexporter: Drop foo (#38000)"}}},
+		"0.121.1": {breakingChanges: {{Text: "elasticsearchexporter: Drop foo (#38000)"}}},
+		"0.122.0": {breakingChanges: {{Text: "elasticsearchexporter: Revert \"Drop foo\" (#38000)"}}},
+	}
+	got := filterComponentChanges(releaseNotes, []string{"elasticsearchexporter"}, mustParseRepo(t, "opentelemetry-collector-contrib"), analysisOptions{})
+	changes := got["elasticsearchexporter"]
+	want := []changeEntry{
+		{Version: "0.121.0", Text: "elasticsearchexporter: Drop foo (#38000)", AlsoIn: []string{"0.121.1"}, RevertedIn: "0.122.0"},
+	}
+	if len(changes[breakingChanges]) != 0 || !reflect.DeepEqual(changes[reverted], want) {
+		t.Errorf("filterComponentChanges() =\n%+v\nbut we expected the reverted changes\n%+v", changes, want)
+	}
+}
+
+func TestFilterComponentChangesRevertInSkippedSection(t *testing.T) {
+	releaseNotes := map[string]map[string][]changeEntry{
+		"0.121.0": {breakingChanges: {{Text: "elasticsearchexporter: Dynamically route documents by default (#38361)", Audience: audienceUser}}},
+		"0.122.0": {
+			skippedSection: {
+				{Text: "elasticsearchexporter: Revert dynamic routing by default, reverts #38361 (#38470)", Audience: audienceUser},
+				{Text: "elasticsearchexporter: Fix flushing (#38480)", Audience: audienceUser},
+			},
+			enhancements: {{Text: "elasticsearchexporter: Add `dedot` setting (#38490)", Audience: audienceAPI}},
+		},
+	}
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	want := []changeEntry{
+		{Version: "0.121.0", Text: "elasticsearchexporter: Dynamically route documents by default (#38361)", Audience: audienceUser, RevertedIn: "0.122.0"},
+	}
+	tests := []struct {
+		name string
+		opts analysisOptions
+	}{
+		{name: "all categories"},
+		{name: "without bug fixes", opts: analysisOptions{Categories: []string{breakingChanges}}},
+		{name: "user changelog", opts: analysisOptions{Audience: audienceUser}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := filterComponentChanges(releaseNotes, []string{"elasticsearchexporter"}, repo, tt.opts)["elasticsearchexporter"]
+			if len(changes[breakingChanges]) != 0 || len(changes[skippedSection]) != 0 || !reflect.DeepEqual(changes[reverted], want) {
+				t.Errorf("filterComponentChanges() =\n%+v\nbut we expected the reverted changes\n%+v", changes, want)
+			}
+		})
+	}
+
+	// A reverted change of a category that is not analyzed is not reported
+	changes := filterComponentChanges(releaseNotes, []string{"elasticsearchexporter"}, repo, analysisOptions{Categories: []string{enhancements}})["elasticsearchexporter"]
+	if len(changes[reverted]) != 0 || len(changes[enhancements]) != 1 {
+		t.Errorf("filterComponentChanges() of enhancements =\n%+v\nbut we expected only the enhancement", changes)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const defaultOwner = "open-telemetry"
+const defaultWebBaseURL = "https://github.com"
+const defaultAPIBaseURL = "https://api.github.com"
+
+// githubRepo identifies a repository on github.com or on a GitHub Enterprise host and builds all its URLs.
+type githubRepo struct {
+	Owner string
+	Name  string
+	// WebBaseURL is the base of the web UI, e.g. https://github.com or https://github.example.com.
+	WebBaseURL string
+	// APIBaseURL is the base of the REST API, e.g. https://api.github.com or https://github.example.com/api/v3.
+	APIBaseURL string
+	// ChangelogPath is the path of a markdown changelog in the repository, e.g. CHANGELOG.md. When set,
+	// releases and their notes are read from the changelog instead of the GitHub releases.
+	ChangelogPath string
+	// ChangelogRef is the git ref the changelog is read at, HEAD by default.
+	ChangelogRef string
+}
+
+// parseRepo parses a repository given as a bare name (owned by open-telemetry), as owner/repo or as a full URL.
+// A markdown changelog of a repository is given as owner/repo/path/CHANGELOG.md or as the URL of the file, e.g.
+// https://github.com/owner/repo/blob/main/CHANGELOG.md. Empty base URLs are derived from the repository URL, or default to github.com.
+func parseRepo(spec, webBaseURL, apiBaseURL string) (githubRepo, error) {
+	spec = strings.TrimSuffix(strings.TrimSpace(spec), "/")
+	if spec == "" {
+		return githubRepo{}, fmt.Errorf("repository is empty")
+	}
+
+	repo := githubRepo{Owner: defaultOwner}
+	path := spec
+	if strings.Contains(spec, "://") {
+		u, err := url.Parse(spec)
+		if err != nil {
+			return githubRepo{}, fmt.Errorf("invalid repository URL %s: %v", spec, err)
+		}
+		if webBaseURL == "" {
+			webBaseURL = fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+		}
+		path = strings.Trim(strings.TrimSuffix(u.Path, ".git"), "/")
+		if !strings.Contains(path, "/") {
+			return githubRepo{}, fmt.Errorf("repository URL %s does not contain owner and repository name", spec)
+		}
+		// The URL of a file, owner/repo/blob/<ref>/<path>
+		if parts := strings.SplitN(path, "/", 5); len(parts) == 5 && parts[2] == "blob" {
+			repo.ChangelogRef = parts[3]
+			path = parts[0] + "/" + parts[1] + "/" + parts[4]
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 && strings.HasSuffix(strings.ToLower(path), ".md") {
+		repo.ChangelogPath = strings.Join(parts[2:], "/")
+		if repo.ChangelogRef == "" {
+			repo.ChangelogRef = "HEAD"
+		}
+		parts = parts[:2]
+	}
+	switch len(parts) {
+	case 1:
+		repo.Name = parts[0]
+	case 2:
+		repo.Owner, repo.Name = parts[0], parts[1]
+	default:
+		return githubRepo{}, fmt.Errorf("invalid repository %s, expected name, owner/name or repository URL", spec)
+	}
+	if repo.Owner == "" || repo.Name == "" {
+		return githubRepo{}, fmt.Errorf("invalid repository %s, expected name, owner/name or repository URL", spec)
+	}
+
+	if webBaseURL == "" {
+		webBaseURL = defaultWebBaseURL
+	}
+	repo.WebBaseURL = strings.TrimSuffix(webBaseURL, "/")
+	if apiBaseURL == "" {
+		apiBaseURL = apiBaseURLFor(repo.WebBaseURL)
+	}
+	repo.APIBaseURL = strings.TrimSuffix(apiBaseURL, "/")
+	return repo, nil
+}
+
+// apiBaseURLFor returns the REST API base URL that belongs to the web base URL.
+// GitHub Enterprise Server serves the API under /api/v3 of the same host.
+func apiBaseURLFor(webBaseURL string) string {
+	if webBaseURL == defaultWebBaseURL {
+		return defaultAPIBaseURL
+	}
+	return webBaseURL + "/api/v3"
+}
+
+// String returns the repository in owner/name form, followed by the path of the changelog when the releases are read from one.
+func (r githubRepo) String() string {
+	if r.ChangelogPath != "" {
+		return r.Owner + "/" + r.Name + "/" + r.ChangelogPath
+	}
+	return r.Owner + "/" + r.Name
+}
+
+// isChangelog reports whether releases are read from a markdown changelog.
+func (r githubRepo) isChangelog() bool {
+	return r.ChangelogPath != ""
+}
+
+// changelogURL returns the raw content of the changelog at its ref.
+func (r githubRepo) changelogURL() string {
+	return fmt.Sprintf("%s/%s/%s/raw/%s/%s", r.WebBaseURL, r.Owner, r.Name, r.ChangelogRef, r.ChangelogPath)
+}
+
+// releasesURL returns the first page of the release listing API.
+func (r githubRepo) releasesURL() string {
+	return fmt.Sprintf("%s/repos/%s/%s/releases?per_page=100", r.APIBaseURL, r.Owner, r.Name)
+}
+
+// releaseURL returns the web page of the release with the given tag.
+func (r githubRepo) releaseURL(tag string) string {
+	return fmt.Sprintf("%s/%s/%s/releases/tag/%s", r.WebBaseURL, r.Owner, r.Name, tag)
+}
+
+// compareURL returns the web page comparing two tags.
+func (r githubRepo) compareURL(oldTag, newTag string) string {
+	return fmt.Sprintf("%s/%s/%s/compare/%s...%s", r.WebBaseURL, r.Owner, r.Name, oldTag, newTag)
+}
+
+// issueURL returns the web page of the issue with the given number.
+func (r githubRepo) issueURL(number string) string {
+	return fmt.Sprintf("%s/%s/%s/issues/%s", r.WebBaseURL, r.Owner, r.Name, number)
+}
+
+// sibling returns another repository on the same host.
+func (r githubRepo) sibling(owner, name string) githubRepo {
+	r.Owner, r.Name = owner, name
+	return r
+}
+
+// commitURL returns the web page of the commit with the given hash.
+func (r githubRepo) commitURL(hash string) string {
+	return fmt.Sprintf("%s/%s/%s/commit/%s", r.WebBaseURL, r.Owner, r.Name, hash)
+}
+
+// vanityModulePaths maps repositories to the import path of their Go modules, when it is not the path of the repository.
+var vanityModulePaths = map[string]string{
+	"open-telemetry/opentelemetry-collector": "go.opentelemetry.io/collector",
+}
+
+// modulePath returns the path the Go modules of the repository start with, e.g. github.com/open-telemetry/opentelemetry-collector-contrib.
+func (r githubRepo) modulePath() string {
+	if path, ok := vanityModulePaths[r.Owner+"/"+r.Name]; ok && r.WebBaseURL == defaultWebBaseURL {
+		return path
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(r.WebBaseURL, "https://"), "http://")
+	return host + "/" + r.Owner + "/" + r.Name
+}
+
+// pullURL returns the web page of the pull request with the given number.
+func (r githubRepo) pullURL(number string) string {
+	return fmt.Sprintf("%s/%s/%s/pull/%s", r.WebBaseURL, r.Owner, r.Name, number)
+}
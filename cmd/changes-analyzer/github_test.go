@@ -0,0 +1,122 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParseRepo(t *testing.T, spec string) githubRepo {
+	t.Helper()
+	repo, err := parseRepo(spec, "", "")
+	if err != nil {
+		t.Fatalf("parseRepo(%q) error = %v", spec, err)
+	}
+	return repo
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		webBaseURL string
+		apiBaseURL string
+		want       githubRepo
+		errMsg     string
+	}{
+		{
+			name: "bare name defaults to open-telemetry on github.com",
+			spec: "opentelemetry-collector",
+			want: githubRepo{Owner: "open-telemetry", Name: "opentelemetry-collector", WebBaseURL: "https://github.com", APIBaseURL: "https://api.github.com"},
+		},
+		{
+			name: "owner and name",
+			spec: "solarwinds/solarwinds-otel-collector-contrib",
+			want: githubRepo{Owner: "solarwinds", Name: "solarwinds-otel-collector-contrib", WebBaseURL: "https://github.com", APIBaseURL: "https://api.github.com"},
+		},
+		{
+			name: "github enterprise URL derives both base URLs",
+			spec: "https://github.example.com/observability/otel-contrib-fork.git",
+			want: githubRepo{Owner: "observability", Name: "otel-contrib-fork", WebBaseURL: "https://github.example.com", APIBaseURL: "https://github.example.com/api/v3"},
+		},
+		{
+			name:       "explicit base URLs win",
+			spec:       "observability/otel-contrib-fork",
+			webBaseURL: "https://ghe.example.com/",
+			apiBaseURL: "https://ghe-api.example.com/",
+			want:       githubRepo{Owner: "observability", Name: "otel-contrib-fork", WebBaseURL: "https://ghe.example.com", APIBaseURL: "https://ghe-api.example.com"},
+		},
+		{
+			name: "changelog in a repository",
+			spec: "solarwinds/solarwinds-otel-collector-contrib/CHANGELOG.md",
+			want: githubRepo{Owner: "solarwinds", Name: "solarwinds-otel-collector-contrib", WebBaseURL: "https://github.com", APIBaseURL: "https://api.github.com", ChangelogPath: "CHANGELOG.md", ChangelogRef: "HEAD"},
+		},
+		{
+			name: "changelog URL with ref",
+			spec: "https://github.example.com/solarwinds/solarwinds-otel-collector-releases/blob/v0.152.4/docs/CHANGELOG.md",
+			want: githubRepo{Owner: "solarwinds", Name: "solarwinds-otel-collector-releases", WebBaseURL: "https://github.example.com", APIBaseURL: "https://github.example.com/api/v3", ChangelogPath: "docs/CHANGELOG.md", ChangelogRef: "v0.152.4"},
+		},
+		{
+			name:   "URL without owner",
+			spec:   "https://github.com/opentelemetry-collector",
+			errMsg: "does not contain owner and repository name",
+		},
+		{
+			name:   "too many path segments",
+			spec:   "a/b/c",
+			errMsg: "expected name, owner/name or repository URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRepo(tt.spec, tt.webBaseURL, tt.apiBaseURL)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("parseRepo() error = %v, but we expected error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRepo() error = %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseRepo() = %+v, but we expected %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGithubRepoURLs(t *testing.T) {
+	repo, err := parseRepo("https://github.example.com/observability/otel-contrib-fork", "", "")
+	if err != nil {
+		t.Fatalf("parseRepo() error = %v", err)
+	}
+	tests := map[string]string{
+		repo.releasesURL():                                               "https://github.example.com/api/v3/repos/observability/otel-contrib-fork/releases?per_page=100",
+		repo.releaseURL("v0.122.0"):                                      "https://github.example.com/observability/otel-contrib-fork/releases/tag/v0.122.0",
+		repo.compareURL("v0.121.0", "v0.122.0"):                          "https://github.example.com/observability/otel-contrib-fork/compare/v0.121.0...v0.122.0",
+		repo.pullURL("38361"):                                            "https://github.example.com/observability/otel-contrib-fork/pull/38361",
+		repo.commitURL("0123abcd"):                                       "https://github.example.com/observability/otel-contrib-fork/commit/0123abcd",
+		repo.modulePath():                                                "github.example.com/observability/otel-contrib-fork",
+		mustParseRepo(t, "opentelemetry-collector").modulePath():         "go.opentelemetry.io/collector",
+		mustParseRepo(t, "opentelemetry-collector-contrib").modulePath(): "github.com/open-telemetry/opentelemetry-collector-contrib",
+	}
+	for got, want := range tests {
+		if got != want {
+			t.Errorf("got URL %q, but we expected %q", got, want)
+		}
+	}
+}
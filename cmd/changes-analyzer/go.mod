@@ -5,9 +5,12 @@ go 1.26.4
 require (
 	github.com/PuerkitoBio/goquery v1.12.0
 	github.com/hashicorp/go-version v1.9.0
+	go.yaml.in/yaml/v3 v3.0.4
+	golang.org/x/exp v0.0.0-20260508232706-74f9aab9d74a
+	golang.org/x/net v0.54.0
 )
 
 require (
 	github.com/andybalholm/cascadia v1.3.3 // indirect
-	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/tools v0.45.0 // indirect
 )
@@ -0,0 +1,180 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// componentCommit is an upstream commit that changed the directory of a component.
+type componentCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	// PRs are the pull requests referenced by the subject, e.g. 38361 for "Add option (#38361)".
+	PRs []string `json:"prs,omitempty"`
+	// Issues are the issues referenced by the body, e.g. 38000 for "Fixes #38000". Changelog entries often reference them instead of the PR.
+	Issues []string `json:"issues,omitempty"`
+}
+
+// exemptCommitPattern matches commits that upstream does not document on purpose, like "[chore]" changes and dependency updates.
+var exemptCommitPattern = regexp.MustCompile(`(?i)^(\[chore\]|chore(\([^)]*\))?:|fix\(deps\):|update module\b)`)
+
+// runGit runs a git command in a local clone and returns its output.
+func runGit(clone string, args ...string) (string, error) {
+	output, err := exec.Command("git", append([]string{"-C", clone}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %v", strings.Join(args, " "), err)
+	}
+	return string(output), nil
+}
+
+// componentDirs finds the directory of every component in the tree of the ref, e.g. exporter/elasticsearchexporter.
+// A directory matches when it is named after the component or one of its aliases, or is the alias path itself, the shallowest one wins.
+func componentDirs(clone, ref string, componentsOfInterest []string, opts analysisOptions) (map[string]string, error) {
+	output, err := runGit(clone, "ls-tree", "-r", "-d", "--name-only", ref)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]string)
+	for _, dir := range strings.Split(strings.TrimSpace(output), "\n") {
+		for _, component := range componentsOfInterest {
+			for _, name := range opts.componentNames(component) {
+				if dir != name && path.Base(dir) != name {
+					continue
+				}
+				if current, ok := dirs[component]; !ok || strings.Count(dir, "/") < strings.Count(current, "/") {
+					dirs[component] = dir
+				}
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// componentCommits lists the commits after the from ref up to the to ref that changed the directory, newest first. Merge commits are skipped.
+func componentCommits(clone, from, to, dir string) ([]componentCommit, error) {
+	output, err := runGit(clone, "log", "--no-merges", "--format=%H%x1f%s%x1f%b%x1e", from+".."+to, "--", dir)
+	if err != nil {
+		return nil, err
+	}
+	var commits []componentCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, componentCommit{Hash: fields[0], Subject: fields[1], PRs: prNumbers(fields[1]), Issues: prNumbers(fields[2])})
+	}
+	return commits, nil
+}
+
+// changelogCommits returns the hashes of the commits after the from ref up to the to ref that add a .chloggen entry.
+// Such commits are documented, whatever the entry references.
+func changelogCommits(clone, from, to string) (map[string]bool, error) {
+	output, err := runGit(clone, "log", "--no-merges", "--diff-filter=A", "--name-only", "--format=%x1e%H", from+".."+to, "--", ".chloggen")
+	if err != nil {
+		return nil, err
+	}
+	commits := make(map[string]bool)
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		for _, file := range lines[1:] {
+			if matched, _ := path.Match(".chloggen/*.yaml", file); matched {
+				commits[lines[0]] = true
+			}
+		}
+	}
+	return commits, nil
+}
+
+// documentedPRs returns the PR and issue numbers referenced by any entry of the release notes, including the entries that are not reported, like bug fixes.
+func documentedPRs(releaseNotes map[string]map[string][]changeEntry) map[string]bool {
+	documented := make(map[string]bool)
+	for _, sections := range releaseNotes {
+		for _, entries := range sections {
+			for _, entry := range entries {
+				for _, number := range prNumbers(entry.Text) {
+					documented[number] = true
+				}
+			}
+		}
+	}
+	return documented
+}
+
+// undocumentedChanges lists the commits of the range that changed the directory of a component, but neither add a .chloggen entry
+// nor reference a PR or an issue referenced by the release notes. Commits without a reference are undocumented too,
+// exempt commits like "[chore]" changes are skipped.
+func undocumentedChanges(clone, from, to string, componentsOfInterest []string, opts analysisOptions, documented map[string]bool) (map[string][]componentCommit, error) {
+	dirs, err := componentDirs(clone, to, componentsOfInterest, opts)
+	if err != nil {
+		return nil, err
+	}
+	withEntries, err := changelogCommits(clone, from, to)
+	if err != nil {
+		return nil, err
+	}
+	undocumented := make(map[string][]componentCommit)
+	for _, component := range componentsOfInterest {
+		dir, ok := dirs[component]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: no directory of %s found in %s at %s, its commits are not analyzed\n", component, clone, to)
+			continue
+		}
+		commits, err := componentCommits(clone, from, to, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			if exemptCommitPattern.MatchString(commit.Subject) || withEntries[commit.Hash] || isDocumented(commit, documented) {
+				continue
+			}
+			undocumented[component] = append(undocumented[component], commit)
+		}
+	}
+	return undocumented, nil
+}
+
+// isDocumented reports whether the release notes reference one of the PRs or issues of the commit.
+func isDocumented(commit componentCommit, documented map[string]bool) bool {
+	for _, number := range append(slices.Clone(commit.PRs), commit.Issues...) {
+		if documented[number] {
+			return true
+		}
+	}
+	return false
+}
+
+// formatUndocumentedChanges writes the undocumented commits of a component as a category of its block.
+func formatUndocumentedChanges(builder *strings.Builder, repo githubRepo, commits []componentCommit) {
+	if len(commits) == 0 {
+		return
+	}
+	builder.WriteString("- **Undocumented Changes**:\n")
+	for _, commit := range commits {
+		builder.WriteString(fmt.Sprintf("  - [%s](%s) %s\n", commit.Hash[:min(len(commit.Hash), 7)], repo.commitURL(commit.Hash), formatEntryText(repo, commit.Subject)))
+	}
+}
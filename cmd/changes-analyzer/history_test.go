@@ -0,0 +1,233 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// historyCommit is a commit of a test clone, changing the files to the given content.
+type historyCommit struct {
+	subject string
+	body    string
+	files   []string
+	tag     string
+}
+
+// writeHistory creates a git repository on branch main with the commits and returns its path.
+func writeHistory(t *testing.T, commits []historyCommit) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	clone := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", clone}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com", "GIT_CONFIG_GLOBAL=/dev/null")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, output)
+		}
+	}
+	gitCmd("init", "-q", "-b", "main")
+	for i, commit := range commits {
+		for _, file := range commit.files {
+			path := filepath.Join(clone, file)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(strings.Repeat("x", i+1)), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		gitCmd("add", "-A")
+		message := commit.subject
+		if commit.body != "" {
+			message += "\n\n" + commit.body
+		}
+		gitCmd("commit", "-q", "-m", message)
+		if commit.tag != "" {
+			gitCmd("tag", commit.tag)
+		}
+	}
+	return clone
+}
+
+var contribHistory = []historyCommit{
+	{subject: "Initial commit", files: []string{"exporter/elasticsearchexporter/config.go", "receiver/prometheusreceiver/config.go", "CHANGELOG.md"}, tag: "v0.121.0"},
+	{subject: "[exporter/elasticsearch] Add the mapping option (#10)", files: []string{"exporter/elasticsearchexporter/config.go"}},
+	{subject: "[exporter/elasticsearch] Refactor the bulk indexer (#11)", files: []string{"exporter/elasticsearchexporter/bulk.go"}},
+	{subject: "[chore] Prepare release v0.122.0 (#12)", files: []string{"exporter/elasticsearchexporter/go.mod", "receiver/prometheusreceiver/go.mod"}},
+	{subject: "Update module github.com/prometheus/common to v0.62.0 (#14)", files: []string{"receiver/prometheusreceiver/go.mod"}},
+	{subject: "[receiver/prometheus] Fix a panic (#13)", files: []string{"receiver/prometheusreceiver/config.go", "receiver/prometheusreceiver/internal/prometheusreceiver/x.go"}, tag: "v0.122.0"},
+	{subject: "Direct push without PR", files: []string{"receiver/prometheusreceiver/scrape.go"}},
+}
+
+func TestUndocumentedChanges(t *testing.T) {
+	clone := writeHistory(t, contribHistory)
+	opts := analysisOptions{Aliases: map[string][]string{"prometheusreceiver": {"receiver/prometheus"}}}
+	components := []string{"elasticsearchexporter", "prometheusreceiver", "otlpreceiver"}
+
+	dirs, err := componentDirs(clone, "main", components, opts)
+	if err != nil {
+		t.Fatalf("componentDirs failed: %v", err)
+	}
+	wantDirs := map[string]string{"elasticsearchexporter": "exporter/elasticsearchexporter", "prometheusreceiver": "receiver/prometheusreceiver"}
+	if !reflect.DeepEqual(dirs, wantDirs) {
+		t.Errorf("componentDirs() returned %v, but we expected %v", dirs, wantDirs)
+	}
+
+	tests := []struct {
+		name       string
+		from, to   string
+		documented map[string]bool
+		want       map[string][]string
+	}{
+		{
+			name:       "release",
+			from:       "v0.121.0",
+			to:         "v0.122.0",
+			documented: map[string]bool{"10": true, "13": true},
+			want:       map[string][]string{"elasticsearchexporter": {"[exporter/elasticsearch] Refactor the bulk indexer (#11)"}},
+		},
+		{
+			name:       "unreleased",
+			from:       "v0.122.0",
+			to:         "main",
+			documented: map[string]bool{},
+			want:       map[string][]string{"prometheusreceiver": {"Direct push without PR"}},
+		},
+		{
+			name: "nothing documented",
+			from: "v0.121.0",
+			to:   "v0.122.0",
+			want: map[string][]string{
+				"elasticsearchexporter": {"[exporter/elasticsearch] Refactor the bulk indexer (#11)", "[exporter/elasticsearch] Add the mapping option (#10)"},
+				"prometheusreceiver":    {"[receiver/prometheus] Fix a panic (#13)"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undocumented, err := undocumentedChanges(clone, tt.from, tt.to, components, opts, tt.documented)
+			if err != nil {
+				t.Fatalf("undocumentedChanges failed: %v", err)
+			}
+			got := make(map[string][]string)
+			for component, commits := range undocumented {
+				for _, commit := range commits {
+					got[component] = append(got[component], commit.Subject)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("undocumentedChanges() returned %v, but we expected %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := undocumentedChanges(clone, "v0.120.0", "v0.122.0", components, opts, nil); err == nil || !strings.Contains(err.Error(), "git log") {
+		t.Errorf("undocumentedChanges() of an unknown tag returned %v, but we expected a git error", err)
+	}
+}
+
+func TestUndocumentedChangesWithChangelogEntries(t *testing.T) {
+	clone := writeHistory(t, []historyCommit{
+		{subject: "Initial commit", files: []string{"exporter/elasticsearchexporter/config.go", ".chloggen/TEMPLATE.yaml"}, tag: "v0.121.0"},
+		{subject: "[exporter/elasticsearch] Add the mapping option (#20)", files: []string{"exporter/elasticsearchexporter/config.go", ".chloggen/mapping.yaml"}},
+		{subject: "[exporter/elasticsearch] Fix retries (#21)", body: "Fixes #15", files: []string{"exporter/elasticsearchexporter/bulk.go"}},
+		{subject: "[exporter/elasticsearch] Refine the mapping option (#22)", body: "Follow-up of #20", files: []string{"exporter/elasticsearchexporter/config.go", ".chloggen/mapping.yaml"}},
+		{subject: "[exporter/elasticsearch] Refactor the bulk indexer (#23)", files: []string{"exporter/elasticsearchexporter/bulk.go", ".chloggen/TEMPLATE.yaml"}, tag: "v0.122.0"},
+	})
+	undocumented, err := undocumentedChanges(clone, "v0.121.0", "v0.122.0", []string{"elasticsearchexporter"}, analysisOptions{}, map[string]bool{"15": true})
+	if err != nil {
+		t.Fatalf("undocumentedChanges failed: %v", err)
+	}
+	// Editing an existing entry, or the template, does not document a commit
+	var got []string
+	for _, commit := range undocumented["elasticsearchexporter"] {
+		got = append(got, commit.Subject)
+	}
+	want := []string{"[exporter/elasticsearch] Refactor the bulk indexer (#23)", "[exporter/elasticsearch] Refine the mapping option (#22)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("undocumentedChanges() returned %v, but we expected %v", got, want)
+	}
+}
+
+func TestDocumentedPRs(t *testing.T) {
+	releaseNotes := map[string]map[string][]changeEntry{
+		"0.122.0": {
+			breakingChanges: {{Text: "`elasticsearchexporter`: Remove option (#10, #11)"}},
+			skippedSection:  {{Text: "`prometheusreceiver`: Fix a panic ([#13](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/13))"}},
+		},
+	}
+	want := map[string]bool{"10": true, "11": true, "13": true}
+	if got := documentedPRs(releaseNotes); !reflect.DeepEqual(got, want) {
+		t.Errorf("documentedPRs() returned %v, but we expected %v", got, want)
+	}
+}
+
+func TestBuildReportWithUndocumentedChanges(t *testing.T) {
+	history := append([]historyCommit{}, contribHistory...)
+	// The pending entry documents the direct push of the elasticsearch exporter, not the one of the prometheus receiver
+	history = append(history, historyCommit{subject: "[exporter/elasticsearch] Add retries (#15)", files: []string{"exporter/elasticsearchexporter/retry.go"}})
+	clone := writeHistory(t, history)
+	files := map[string]string{
+		"CHANGELOG.md":           "## v0.122.0\n\n## v0.121.0\n",
+		".chloggen/retries.yaml": "change_type: enhancement\ncomponent: elasticsearchexporter\nnote: Add retries\nissues: [15]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(clone, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	opts := analysisOptions{Range: versionRange{Chloggen: clone}, Clone: clone}
+	report, err := buildReport([]string{"elasticsearchexporter", "prometheusreceiver"}, repo, opts)
+	if err != nil {
+		t.Fatalf("buildReport failed: %v", err)
+	}
+	hash := report.Undocumented["prometheusreceiver"][0].Hash
+	for _, tt := range []struct {
+		groupBy string
+		want    string
+	}{
+		{
+			groupBy: groupByComponent,
+			want: "#### elasticsearchexporter\n- **Enhancements**:\n  - 0.123.0-unreleased: `elasticsearchexporter`: Add retries " +
+				"([#15](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/15))\n\n---\n" +
+				"#### prometheusreceiver\n- **Undocumented Changes**:\n  - [" + hash[:7] + "](https://github.com/open-telemetry/opentelemetry-collector-contrib/commit/" + hash + ") Direct push without PR\n",
+		},
+		{
+			groupBy: groupByVersion,
+			want: "### Undocumented changes\n\n#### prometheusreceiver\n- **Undocumented Changes**:\n  - [" + hash[:7] +
+				"](https://github.com/open-telemetry/opentelemetry-collector-contrib/commit/" + hash + ") Direct push without PR\n",
+		},
+	} {
+		if message := report.formatMarkdown(tt.groupBy); !strings.Contains(message, tt.want) {
+			t.Errorf("report grouped by %s does not contain %q:\n%s", tt.groupBy, tt.want, message)
+		}
+	}
+}
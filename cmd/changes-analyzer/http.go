@@ -0,0 +1,154 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var client = &http.Client{Timeout: defaultRetryPolicy().Timeout}
+
+// retryPolicy controls how HTTP requests are timed out and retried.
+type retryPolicy struct {
+	// Timeout is applied to every single attempt, including reading the body.
+	Timeout time.Duration
+	// MaxRetries is the number of attempts made after the first one failed.
+	MaxRetries int
+	// InitialBackoff is the delay before the second attempt, doubled for every following one.
+	InitialBackoff time.Duration
+	// MaxBackoff caps both the exponential backoff and the Retry-After delay.
+	MaxBackoff time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		Timeout:        30 * time.Second,
+		MaxRetries:     3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// httpPolicy is the retry policy used by getResponse.
+var httpPolicy = defaultRetryPolicy()
+
+// sleep is replaced in tests so that retries do not slow them down.
+var sleep = time.Sleep
+
+// setRetryPolicy applies the policy to the shared client.
+func setRetryPolicy(policy retryPolicy) {
+	if policy.MaxRetries < 0 {
+		policy.MaxRetries = 0
+	}
+	httpPolicy = policy
+	client.Timeout = policy.Timeout
+}
+
+// isRetryableStatus reports whether the status code is worth another attempt.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// backoff returns the delay before the given retry (1 for the first retry), jittered between half and the full value.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff << (retry - 1)
+	if delay <= 0 || delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// getResponse calls http.Get for given url and returns the response.
+// Responses are served from responseCache when one is configured.
+func getResponse(url string) (*http.Response, error) {
+	if responseCache != nil {
+		return responseCache.get(url)
+	}
+	return fetchWithRetry(url, nil)
+}
+
+// fetchWithRetry sends a GET request with the given extra headers. Connection errors, 429 and 5xx
+// responses are retried according to httpPolicy. 304 is accepted only for conditional requests.
+func fetchWithRetry(url string, header http.Header) (*http.Response, error) {
+	conditional := header.Get("If-None-Match") != "" || header.Get("If-Modified-Since") != ""
+	attempts := httpPolicy.MaxRetries + 1
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for url %s: %v", url, err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := client.Do(req)
+		var retryAfter time.Duration
+		var hasRetryAfter bool
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode == http.StatusOK, conditional && resp.StatusCode == http.StatusNotModified:
+			return resp, nil
+		default:
+			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+			retryAfter, hasRetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if !isRetryableStatus(resp.StatusCode) {
+				return nil, fmt.Errorf("GET request status is %d for url %s", resp.StatusCode, url)
+			}
+		}
+
+		if attempt == attempts {
+			break
+		}
+		delay := httpPolicy.backoff(attempt)
+		if hasRetryAfter {
+			delay = min(retryAfter, httpPolicy.MaxBackoff)
+		}
+		sleep(delay)
+	}
+	return nil, fmt.Errorf("GET request for url %s failed after %d attempts: %v", url, attempts, lastErr)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sequenceTransport returns the queued results one by one, regardless of the requested URL.
+type sequenceTransport struct {
+	results []func() (*http.Response, error)
+	calls   int
+}
+
+func (t *sequenceTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	result := t.results[t.calls]
+	t.calls++
+	return result()
+}
+
+func statusResponse(code int, header http.Header) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader("body"))}, nil
+	}
+}
+
+func withTestRetryPolicy(t *testing.T, transport http.RoundTripper) *[]time.Duration {
+	t.Helper()
+	var slept []time.Duration
+	originalTransport, originalPolicy, originalSleep := client.Transport, httpPolicy, sleep
+	client.Transport = transport
+	setRetryPolicy(retryPolicy{Timeout: time.Second, MaxRetries: 2, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second})
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	t.Cleanup(func() {
+		client.Transport = originalTransport
+		setRetryPolicy(originalPolicy)
+		sleep = originalSleep
+	})
+	return &slept
+}
+
+func TestGetResponseRetries(t *testing.T) {
+	transport := &sequenceTransport{results: []func() (*http.Response, error){
+		func() (*http.Response, error) { return nil, errors.New("connection reset") },
+		statusResponse(http.StatusBadGateway, nil),
+		statusResponse(http.StatusOK, nil),
+	}}
+	slept := withTestRetryPolicy(t, transport)
+
+	resp, err := getResponse("https://example.com/releases")
+	if err != nil {
+		t.Fatalf("getResponse() error = %v, want nil", err)
+	}
+	resp.Body.Close()
+	if transport.calls != 3 {
+		t.Errorf("getResponse() made %d calls, but we expected 3", transport.calls)
+	}
+	if len(*slept) != 2 {
+		t.Fatalf("getResponse() slept %d times, but we expected 2", len(*slept))
+	}
+	if d := (*slept)[0]; d < 50*time.Millisecond || d > 100*time.Millisecond {
+		t.Errorf("first backoff = %v, but we expected it between 50ms and 100ms", d)
+	}
+	if d := (*slept)[1]; d < 100*time.Millisecond || d > 200*time.Millisecond {
+		t.Errorf("second backoff = %v, but we expected it between 100ms and 200ms", d)
+	}
+}
+
+func TestGetResponseHonoursRetryAfter(t *testing.T) {
+	transport := &sequenceTransport{results: []func() (*http.Response, error){
+		statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}),
+		statusResponse(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"120"}}),
+		statusResponse(http.StatusOK, nil),
+	}}
+	slept := withTestRetryPolicy(t, transport)
+
+	resp, err := getResponse("https://example.com/releases")
+	if err != nil {
+		t.Fatalf("getResponse() error = %v, want nil", err)
+	}
+	resp.Body.Close()
+	want := []time.Duration{time.Second, time.Second} // second one is capped by MaxBackoff
+	if len(*slept) != len(want) || (*slept)[0] != want[0] || (*slept)[1] != want[1] {
+		t.Errorf("getResponse() slept %v, but we expected %v", *slept, want)
+	}
+}
+
+func TestGetResponseFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []func() (*http.Response, error)
+		wantCalls int
+		errMsg    string
+	}{
+		{
+			name: "gives up after all attempts",
+			results: []func() (*http.Response, error){
+				statusResponse(http.StatusBadGateway, nil),
+				statusResponse(http.StatusBadGateway, nil),
+				statusResponse(http.StatusBadGateway, nil),
+			},
+			wantCalls: 3,
+			errMsg:    "GET request for url https://example.com/releases failed after 3 attempts: status 502",
+		},
+		{
+			name: "does not retry client errors",
+			results: []func() (*http.Response, error){
+				statusResponse(http.StatusNotFound, nil),
+			},
+			wantCalls: 1,
+			errMsg:    "GET request status is 404 for url https://example.com/releases",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &sequenceTransport{results: tt.results}
+			withTestRetryPolicy(t, transport)
+
+			_, err := getResponse("https://example.com/releases")
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("getResponse() error = %v, but we expected error containing %q", err, tt.errMsg)
+			}
+			if transport.calls != tt.wantCalls {
+				t.Errorf("getResponse() made %d calls, but we expected %d", transport.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "", want: 0, wantOk: false},
+		{value: "5", want: 5 * time.Second, wantOk: true},
+		{value: "-1", want: 0, wantOk: false},
+		{value: "Sat, 01 Mar 2025 12:00:30 GMT", want: 30 * time.Second, wantOk: true},
+		{value: "Sat, 01 Mar 2025 11:00:00 GMT", want: 0, wantOk: true},
+		{value: "soon", want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, but we expected %v, %v", tt.value, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	lintUnknownComponent    = "unknown_component"
+	lintUnverifiedComponent = "unverified_component"
+	lintUnknownTelemetry    = "unknown_telemetry"
+	lintUnknownOption       = "unknown_option"
+)
+
+// lintFinding is a problem of an example config, at the line of the offending key.
+type lintFinding struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Component is the ID of the component in the config, e.g. receivers/mysql/events.
+	Component string `json:"component"`
+	Kind      string `json:"kind"`
+	Message   string `json:"message"`
+}
+
+// configSections maps the sections of a collector config to the class of their components in metadata.yaml.
+var configSections = map[string]string{
+	"receivers":  "receiver",
+	"processors": "processor",
+	"exporters":  "exporter",
+	"extensions": "extension",
+	"connectors": "connector",
+}
+
+// pinnedComponent is a component module required by one of our go.mod files.
+type pinnedComponent struct {
+	// Name is the last element of the module path, the name of the component in the verified distribution list.
+	Name    string
+	Path    string
+	Version string
+	// Dir is the directory of the module in the module cache, empty when it was not downloaded.
+	Dir string
+}
+
+func (c pinnedComponent) String() string {
+	return c.Name + " " + c.Version
+}
+
+// componentIndex maps the class and type of a component, e.g. receiver/mysql, to its module.
+type componentIndex map[string]pinnedComponent
+
+// pinnedComponents indexes the modules directly required by the go.mod files by the class and type of their metadata.yaml,
+// e.g. receiver/mysql. Modules missing in the module cache are indexed by their name, e.g. mysqlreceiver.
+func pinnedComponents(modCache string, goModPaths []string) (componentIndex, error) {
+	index := make(componentIndex)
+	for _, goModPath := range goModPaths {
+		goMod, err := readGoMod(goModPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, require := range goMod.Requires {
+			if require.Indirect {
+				continue
+			}
+			component := pinnedComponent{Name: path.Base(require.Path), Path: require.Path, Version: require.Version}
+			dir := moduleCacheDir(modCache, require.Path, require.Version)
+			if _, err := os.Stat(dir); err != nil {
+				index[component.Name] = component
+				continue
+			}
+			metadata, err := readComponentMetadata(dir)
+			if err != nil || metadata.Type == "" {
+				// Not a component, e.g. a library
+				continue
+			}
+			component.Dir = dir
+			index[metadata.Status.Class+"/"+metadata.Type] = component
+		}
+	}
+	return index, nil
+}
+
+// lookup finds the module of a component of the given class and type. The name of a module missing in the module cache
+// is guessed from the type, like host_metrics for hostmetricsreceiver, or file_storage for the filestorage extension.
+func (index componentIndex) lookup(class, componentType string) (pinnedComponent, bool) {
+	if component, ok := index[class+"/"+componentType]; ok {
+		return component, true
+	}
+	name := strings.ReplaceAll(componentType, "_", "")
+	for _, candidate := range []string{name + class, name} {
+		if component, ok := index[candidate]; ok {
+			return component, true
+		}
+	}
+	return pinnedComponent{}, false
+}
+
+// verifiedComponentPattern matches the rows of the generated list of the verified distribution,
+// e.g. | Receiver | [mysqlreceiver](https://...) | No |.
+var verifiedComponentPattern = regexp.MustCompile(`^\|\s*[A-Za-z]+\s*\|\s*\[([^\]]+)\]`)
+
+// readVerifiedComponents reads the component names of the verified distribution list, docs/verified-components.md.
+func readVerifiedComponents(listPath string) (map[string]bool, error) {
+	file, err := os.Open(listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the verified distribution list: %v", err)
+	}
+	defer file.Close()
+	verified := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if match := verifiedComponentPattern.FindStringSubmatch(scanner.Text()); match != nil {
+			verified[match[1]] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the verified distribution list: %v", err)
+	}
+	return verified, nil
+}
+
+// componentLinter checks the components of example configs against the modules pinned by our go.mod files.
+type componentLinter struct {
+	modCache   string
+	components componentIndex
+	// verified is nil when the verified distribution list is not available, the check is skipped then.
+	verified map[string]bool
+	// telemetry and schemas cache the metadata and Config structs of the modules by directory.
+	telemetry map[string]map[string]telemetryMetadata
+	schemas   map[string]configSchema
+}
+
+func newComponentLinter(modCache string, components componentIndex, verified map[string]bool) *componentLinter {
+	return &componentLinter{
+		modCache:   modCache,
+		components: components,
+		verified:   verified,
+		telemetry:  make(map[string]map[string]telemetryMetadata),
+		schemas:    make(map[string]configSchema),
+	}
+}
+
+// lintExampleConfigs lints the YAML files of the examples directory, the files of the findings are relative to it.
+func (l *componentLinter) lintExampleConfigs(dir string) ([]lintFinding, error) {
+	var findings []lintFinding
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (filepath.Ext(filePath) != ".yaml" && filepath.Ext(filePath) != ".yml") {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping example config %s: %v\n", filePath, err)
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, l.lintConfig(&root, filepath.ToSlash(rel))...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lint example configs in %s: %v", dir, err)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// mappingEntries returns the key and value nodes of a mapping, nothing for other nodes.
+func mappingEntries(node *yaml.Node) [][2]*yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	entries := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries = append(entries, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	return entries
+}
+
+// lintConfig lints the components of the sections of a collector config.
+func (l *componentLinter) lintConfig(root *yaml.Node, file string) []lintFinding {
+	var findings []lintFinding
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+	for _, section := range mappingEntries(root.Content[0]) {
+		class, ok := configSections[section[0].Value]
+		if !ok {
+			continue
+		}
+		for _, entry := range mappingEntries(section[1]) {
+			id := entry[0].Value
+			componentType, _, _ := strings.Cut(id, "/")
+			report := func(line int, kind, format string, args ...any) {
+				findings = append(findings, lintFinding{File: file, Line: line, Component: section[0].Value + "/" + id, Kind: kind, Message: fmt.Sprintf(format, args...)})
+			}
+			component, ok := l.components.lookup(class, componentType)
+			if !ok {
+				report(entry[0].Line, lintUnknownComponent, "%s `%s` is not provided by any module of the go.mod files", class, componentType)
+				continue
+			}
+			if l.verified != nil && !l.verified[component.Name] {
+				report(entry[0].Line, lintUnverifiedComponent, "%s is not in the verified distribution", component.Name)
+			}
+			if component.Dir == "" {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s of %s: module %s@%s not found in the module cache %s, download it with go mod download\n",
+					id, file, component.Path, component.Version, l.modCache)
+				continue
+			}
+			l.lintComponent(entry[1], component, report)
+		}
+	}
+	return findings
+}
+
+// lintComponent checks the telemetry sections of a component config against its metadata.yaml files and its options
+// against its Config struct.
+func (l *componentLinter) lintComponent(config *yaml.Node, component pinnedComponent, report func(line int, kind, format string, args ...any)) {
+	metadata, ok := l.telemetry[component.Dir]
+	if !ok {
+		var err error
+		if metadata, err = readTelemetryMetadata(component.Dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping telemetry of %s: %v\n", component, err)
+		}
+		l.telemetry[component.Dir] = metadata
+	}
+	checkTelemetry := func(config *yaml.Node, metadata telemetryMetadata, owner string) {
+		for _, entry := range mappingEntries(config) {
+			if !slices.Contains(telemetrySections, entry[0].Value) {
+				continue
+			}
+			definitions := metadata.section(entry[0].Value)
+			for _, name := range mappingEntries(entry[1]) {
+				if _, ok := definitions[name[0].Value]; !ok {
+					report(name[0].Line, lintUnknownTelemetry, "%s `%s` is not defined by %s",
+						strings.TrimSuffix(strings.ReplaceAll(entry[0].Value, "_", " "), "s"), name[0].Value, owner)
+				}
+			}
+		}
+	}
+	if root, ok := metadata["metadata.yaml"]; ok {
+		checkTelemetry(config, root, component.String())
+	}
+	// Scrapers of a receiver have their own metadata.yaml, whose type is the name of the scraper
+	for _, entry := range mappingEntries(config) {
+		if entry[0].Value != "scrapers" {
+			continue
+		}
+		for _, scraper := range mappingEntries(entry[1]) {
+			for file, scraperMetadata := range metadata {
+				if file != "metadata.yaml" && scraperMetadata.Type == scraper[0].Value {
+					checkTelemetry(scraper[1], scraperMetadata, fmt.Sprintf("the %s scraper of %s", scraper[0].Value, component))
+				}
+			}
+		}
+	}
+
+	schema, ok := l.schemas[component.Dir]
+	if !ok {
+		var err error
+		if schema, err = readConfigSchema(l.modCache, component.Dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping options of %s: %v\n", component, err)
+		}
+		l.schemas[component.Dir] = schema
+	}
+	if schema != nil {
+		lintOptions(config, "", schema, func(key *yaml.Node, option string) {
+			report(key.Line, lintUnknownOption, "option `%s` is not in the Config of %s", option, component)
+		})
+	}
+}
+
+// lintOptions reports the keys of a config mapping that are not in the schema. Telemetry sections are checked against
+// metadata.yaml instead, and keys below fields whose keys are unknown, like maps, are not checked.
+func lintOptions(node *yaml.Node, prefix string, schema configSchema, report func(key *yaml.Node, option string)) {
+	for _, entry := range mappingEntries(node) {
+		option := prefix + entry[0].Value
+		if prefix == "" && slices.Contains(telemetrySections, entry[0].Value) {
+			continue
+		}
+		if !schema.has(option) {
+			report(entry[0], option)
+			continue
+		}
+		if !schema[option].Nested {
+			continue
+		}
+		switch entry[1].Kind {
+		case yaml.MappingNode:
+			lintOptions(entry[1], option+".", schema, report)
+		case yaml.SequenceNode:
+			for _, item := range entry[1].Content {
+				lintOptions(item, option+".", schema, report)
+			}
+		}
+	}
+}
+
+// formatLintFindings formats the findings as Markdown, one list per example config.
+func formatLintFindings(findings []lintFinding) string {
+	var builder strings.Builder
+	builder.WriteString("# Example config lint\n")
+	if len(findings) == 0 {
+		builder.WriteString("\nNo problems found in the example configs.\n")
+		return builder.String()
+	}
+	file := ""
+	for _, finding := range findings {
+		if finding.File != file {
+			file = finding.File
+			builder.WriteString(fmt.Sprintf("\n#### %s\n", file))
+		}
+		builder.WriteString(fmt.Sprintf("- line %d, `%s`: %s\n", finding.Line, finding.Component, finding.Message))
+	}
+	return builder.String()
+}
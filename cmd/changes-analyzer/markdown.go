@@ -0,0 +1,285 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// listItemMarkdown converts the content of a release notes list item to markdown.
+// Paragraphs are separated by blank lines and nested lists are indented under their parent item,
+// relative to the start of the item, so that callers only have to indent continuation lines.
+func listItemMarkdown(item *goquery.Selection) string {
+	var blocks []string
+	for _, node := range item.Nodes {
+		blocks = append(blocks, markdownBlocks(node)...)
+	}
+	return joinBlocks(blocks)
+}
+
+// blockElements are the elements rendered as separate markdown blocks.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "ul": true, "ol": true, "pre": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// markdownBlocks renders the children of a node as markdown blocks. Inline content between block elements forms a paragraph.
+func markdownBlocks(n *html.Node) []string {
+	var blocks []string
+	var inline strings.Builder
+	flush := func() {
+		lines := strings.Split(strings.TrimSpace(inline.String()), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		if text := strings.Join(lines, "\n"); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !blockElements[child.Data] {
+			writeInline(&inline, child)
+			continue
+		}
+		flush()
+		switch child.Data {
+		case "ul", "ol":
+			if list := markdownList(child); list != "" {
+				blocks = append(blocks, list)
+			}
+		case "pre":
+			code := strings.TrimRight(textContent(child), "\n")
+			blocks = append(blocks, "```\n"+code+"\n```")
+		case "blockquote":
+			if quote := joinBlocks(markdownBlocks(child)); quote != "" {
+				blocks = append(blocks, prefixLines(quote, "> ", ">"))
+			}
+		default:
+			blocks = append(blocks, markdownBlocks(child)...)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// joinBlocks separates paragraphs by blank lines. A list directly follows the preceding paragraph, like sub-bullets of an entry.
+func joinBlocks(blocks []string) string {
+	var builder strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			if isMarkdownList(block) {
+				builder.WriteString("\n")
+			} else {
+				builder.WriteString("\n\n")
+			}
+		}
+		builder.WriteString(block)
+	}
+	return builder.String()
+}
+
+// listMarkerPattern matches the marker of the first item of a rendered list.
+var listMarkerPattern = regexp.MustCompile(`^(- |\d+\. )`)
+
+func isMarkdownList(block string) bool {
+	return listMarkerPattern.MatchString(block)
+}
+
+// markdownList renders a <ul> or <ol> element. Continuation lines of an item are indented to the start of its text.
+func markdownList(list *html.Node) string {
+	var items []string
+	number := 1
+	for child := list.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.Data != "li" {
+			continue
+		}
+		content := joinBlocks(markdownBlocks(child))
+		if content == "" {
+			continue
+		}
+		marker := "- "
+		if list.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		items = append(items, marker+indentContinuation(content, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// writeInline renders inline content. Whitespace is collapsed like a browser would, except inside code spans
+// and for the line break starting the sub-text of an entry, see writeText.
+func writeInline(builder *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		writeText(builder, n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	switch n.Data {
+	case "code":
+		builder.WriteString(codeSpan(textContent(n)))
+	case "br":
+		builder.WriteString("\n")
+	case "strong", "b":
+		writeWrapped(builder, n, "**")
+	case "em", "i":
+		writeWrapped(builder, n, "_")
+	case "a":
+		writeLink(builder, n)
+	case "img":
+		builder.WriteString(attr(n, "alt"))
+	default:
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			writeInline(builder, child)
+		}
+	}
+}
+
+// trailingReferencesPattern matches the references closing the first line of a changelog entry, e.g. (#38361) or (#1, #2).
+var trailingReferencesPattern = regexp.MustCompile(`\((\[?([\w.-]+/[\w.-]+)?#\d+(\]\([^)\s]*\))?(,\s*)?)+\)$`)
+
+// writeText writes a text node with its whitespace collapsed. A line break following the references that close
+// the first line of an entry is kept, as changelogs write the sub-text of an entry on its own line after them.
+func writeText(builder *strings.Builder, text string) {
+	last := 0
+	for _, loc := range spacePattern.FindAllStringIndex(text, -1) {
+		builder.WriteString(text[last:loc[0]])
+		written := builder.String()
+		switch {
+		case !strings.Contains(text[loc[0]:loc[1]], "\n") || !trailingReferencesPattern.MatchString(strings.TrimSpace(written)):
+			builder.WriteString(" ")
+		case !strings.HasSuffix(written, "\n"):
+			builder.WriteString("\n")
+		}
+		last = loc[1]
+	}
+	builder.WriteString(text[last:])
+}
+
+func writeWrapped(builder *strings.Builder, n *html.Node, marker string) {
+	var inner strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeInline(&inner, child)
+	}
+	if text := strings.TrimSpace(inner.String()); text != "" {
+		builder.WriteString(marker + text + marker)
+	}
+}
+
+// referencePattern matches the text of links GitHub creates for references, e.g. #123, owner/repo#123 or @user.
+// They are kept as plain text, so that the report links them consistently.
+var referencePattern = regexp.MustCompile(`^(#\d+|[\w.-]+/[\w.-]+#\d+|@[\w-]+(/[\w-]+)?)$`)
+
+// writeLink renders a link, GitHub references are written as their plain text.
+// References GitHub resolved to an issue or a pull request keep their link, which tells the two apart.
+func writeLink(builder *strings.Builder, n *html.Node) {
+	var inner strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeInline(&inner, child)
+	}
+	text := strings.TrimSpace(inner.String())
+	href := attr(n, "href")
+	if referencePattern.MatchString(text) && href != "" {
+		switch attr(n, "data-hovercard-type") {
+		case "issue", "pull_request":
+			builder.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+			return
+		}
+	}
+	if href == "" || referencePattern.MatchString(text) || text == href {
+		builder.WriteString(text)
+		return
+	}
+	if text == "" {
+		text = href
+	}
+	builder.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+}
+
+// codeSpan wraps code in backticks, using a longer fence when the code itself contains backticks.
+func codeSpan(code string) string {
+	code = strings.Join(strings.Fields(code), " ")
+	if code == "" {
+		return ""
+	}
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// textContent returns the raw text of a node and its descendants.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var builder strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(textContent(child))
+	}
+	return builder.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+var spacePattern = regexp.MustCompile(`\s+`)
+
+func collapseSpace(text string) string {
+	return spacePattern.ReplaceAllString(text, " ")
+}
+
+// indentContinuation indents every line but the first, leaving blank lines empty.
+func indentContinuation(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixLines prefixes every line, using blankPrefix for blank lines.
+func prefixLines(text, prefix, blankPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = blankPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestListItemMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "plain text",
+			html: `<li>  otlpreceiver:   Fix
+				 crash (#1)  </li>`,
+			want: "otlpreceiver: Fix crash (#1)",
+		},
+		{
+			name: "code spans",
+			html: "<li><code>elasticsearchexporter</code>: Deprecate <code>mapping::mode</code> and <code>a`b</code></li>",
+			want: "`elasticsearchexporter`: Deprecate `mapping::mode` and ``a`b``",
+		},
+		{
+			name: "links and references",
+			html: `<li>See <a href="https://opentelemetry.io/docs">the docs</a> (<a href="https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/38361">#38361</a>, <a class="user-mention" href="https://github.com/someone">@someone</a>)</li>`,
+			want: "See [the docs](https://opentelemetry.io/docs) (#38361, @someone)",
+		},
+		{
+			name: "emphasis and line breaks",
+			html: `<li><p><strong>Breaking</strong> change in <em>routing</em><br>
+				Overhaul in document routing.</p></li>`,
+			want: "**Breaking** change in _routing_\nOverhaul in document routing.",
+		},
+		{
+			name: "sub-text after the references",
+			html: `<li>elasticsearchexporter: Route documents dynamically (<a href="https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/38361">#38361</a>, #38362)
+Overhaul in document routing,
+see the docs.</li>`,
+			want: "elasticsearchexporter: Route documents dynamically (#38361, #38362)\nOverhaul in document routing, see the docs.",
+		},
+		{
+			name: "sub-text after a line break and the references",
+			html: `<li>elasticsearchexporter: Route documents dynamically (#38361)<br>
+Overhaul in document routing.</li>`,
+			want: "elasticsearchexporter: Route documents dynamically (#38361)\nOverhaul in document routing.",
+		},
+		{
+			name: "paragraphs",
+			html: `<li><p>kafkareceiver: Add SASL support (#2)</p><p>Configure it with the <code>auth</code> section.</p></li>`,
+			want: "kafkareceiver: Add SASL support (#2)\n\nConfigure it with the `auth` section.",
+		},
+		{
+			name: "nested lists",
+			html: `<li><p>pkg/ottl: Rename functions (#3)</p>
+				<ul>
+					<li><code>IsMatch</code> is now <code>Matches</code>
+						<ol><li>first</li><li>second<br>continued</li></ol>
+					</li>
+					<li>Other functions keep their names</li>
+				</ul></li>`,
+			want: "pkg/ottl: Rename functions (#3)\n" +
+				"- `IsMatch` is now `Matches`\n" +
+				"  1. first\n" +
+				"  2. second\n" +
+				"     continued\n" +
+				"- Other functions keep their names",
+		},
+		{
+			name: "text directly followed by a list",
+			html: `<li>hostmetricsreceiver: New metrics
+				<ul><li>system.uptime</li></ul></li>`,
+			want: "hostmetricsreceiver: New metrics\n- system.uptime",
+		},
+		{
+			name: "code block",
+			html: "<li>Example:<pre><code>receivers:\n  otlp:\n</code></pre></li>",
+			want: "Example:\n\n```\nreceivers:\n  otlp:\n```",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader("<ul>" + tt.html + "</ul>"))
+			if err != nil {
+				t.Fatalf("failed to parse HTML: %v", err)
+			}
+			if got := listItemMarkdown(doc.Find("li").First()); got != tt.want {
+				t.Errorf("listItemMarkdown() returned %q, but we expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatNestedEntries(t *testing.T) {
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	entry := changeEntry{
+		Version: "0.122.0",
+		Text:    "`pkg/ottl`: Rename functions (#3)\n- `IsMatch` is now `Matches`\n  1. see [docs](https://example.com/a.b)\n\nMore details.",
+		AlsoIn:  []string{"0.122.1"},
+	}
+
+	var builder strings.Builder
+	formatCategories(&builder, repo, categoryToChangesMap{breakingChanges: {entry}}, true)
+	want := "- **Breaking Changes**:\n" +
+		"  - 0.122.0 (also in 0.122.1): `pkg/ottl`: Rename functions ([#3](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/3))\n" +
+		"    - `IsMatch` is now `Matches`\n" +
+		"      1. see [docs](https://example.com/a.b)\n" +
+		"\n" +
+		"    More details.\n"
+	if got := builder.String(); got != want {
+		t.Errorf("formatCategories() returned %q, but we expected %q", got, want)
+	}
+
+	builder.Reset()
+	formatCategories(&builder, repo, categoryToChangesMap{breakingChanges: {entry}}, false)
+	if got := builder.String(); !strings.HasPrefix(got, "- **Breaking Changes**:\n  - `pkg/ottl`: Rename functions ([#3](https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/3)) (also in 0.122.1)\n    - `IsMatch`") {
+		t.Errorf("formatCategories() without versions returned %q, the notes should follow the first line", got)
+	}
+}
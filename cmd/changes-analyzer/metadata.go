@@ -0,0 +1,313 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// componentMetadata is the part of the metadata.yaml of an upstream component that describes its status.
+type componentMetadata struct {
+	Type   string         `yaml:"type"`
+	Status metadataStatus `yaml:"status"`
+}
+
+type metadataStatus struct {
+	Class string `yaml:"class"`
+	// Stability maps stability levels to signals, e.g. beta: [traces, logs].
+	Stability     map[string][]string `yaml:"stability"`
+	Distributions []string            `yaml:"distributions"`
+	Codeowners    metadataCodeowners  `yaml:"codeowners"`
+}
+
+type metadataCodeowners struct {
+	Active     []string `yaml:"active"`
+	Emeritus   []string `yaml:"emeritus"`
+	SeekingNew bool     `yaml:"seeking_new"`
+}
+
+const (
+	stabilityDeprecated   = "deprecated"
+	stabilityUnmaintained = "unmaintained"
+)
+
+// stabilityRanks orders the stability levels of maintained components. Moving to a lower rank is a downgrade.
+var stabilityRanks = map[string]int{"development": 0, "alpha": 1, "beta": 2, "stable": 3}
+
+// signalStability returns the stability level of every signal of the component.
+func (m componentMetadata) signalStability() map[string]string {
+	levels := make(map[string]string)
+	for level, signals := range m.Status.Stability {
+		for _, signal := range signals {
+			levels[signal] = level
+		}
+	}
+	return levels
+}
+
+// stabilityChange is the stability of a signal in the old and in the new version. New is empty when the signal was removed.
+type stabilityChange struct {
+	Signal string `json:"signal"`
+	Old    string `json:"old"`
+	New    string `json:"new,omitempty"`
+}
+
+// metadataDiff holds the status changes of a component between two versions of its metadata.yaml.
+type metadataDiff struct {
+	StabilityDowngrades []stabilityChange `json:"stability_downgrades,omitempty"`
+	// Deprecated and Unmaintained list the signals that became deprecated or unmaintained.
+	Deprecated        []string `json:"deprecated,omitempty"`
+	Unmaintained      []string `json:"unmaintained,omitempty"`
+	CodeownersAdded   []string `json:"codeowners_added,omitempty"`
+	CodeownersRemoved []string `json:"codeowners_removed,omitempty"`
+	// SeekingNewCodeowners is set when the component started seeking new codeowners.
+	SeekingNewCodeowners bool `json:"seeking_new_codeowners,omitempty"`
+}
+
+func (d metadataDiff) isEmpty() bool {
+	return len(d.StabilityDowngrades) == 0 && len(d.Deprecated) == 0 && len(d.Unmaintained) == 0 &&
+		len(d.CodeownersAdded) == 0 && len(d.CodeownersRemoved) == 0 && !d.SeekingNewCodeowners
+}
+
+// diffMetadata compares the old and new metadata of a component.
+func diffMetadata(old, new componentMetadata) metadataDiff {
+	var diff metadataDiff
+	oldLevels, newLevels := old.signalStability(), new.signalStability()
+	for _, signal := range slices.Sorted(maps.Keys(oldLevels)) {
+		oldLevel, newLevel := oldLevels[signal], newLevels[signal]
+		if oldLevel == newLevel {
+			continue
+		}
+		oldRank, ranked := stabilityRanks[oldLevel]
+		newRank, newRanked := stabilityRanks[newLevel]
+		if ranked && (newLevel == "" || newRanked && newRank < oldRank) {
+			diff.StabilityDowngrades = append(diff.StabilityDowngrades, stabilityChange{Signal: signal, Old: oldLevel, New: newLevel})
+		}
+	}
+	for _, signal := range slices.Sorted(maps.Keys(newLevels)) {
+		if newLevels[signal] == oldLevels[signal] {
+			continue
+		}
+		switch newLevels[signal] {
+		case stabilityDeprecated:
+			diff.Deprecated = append(diff.Deprecated, signal)
+		case stabilityUnmaintained:
+			diff.Unmaintained = append(diff.Unmaintained, signal)
+		}
+	}
+	for _, owner := range new.Status.Codeowners.Active {
+		if !slices.Contains(old.Status.Codeowners.Active, owner) {
+			diff.CodeownersAdded = append(diff.CodeownersAdded, owner)
+		}
+	}
+	for _, owner := range old.Status.Codeowners.Active {
+		if !slices.Contains(new.Status.Codeowners.Active, owner) {
+			diff.CodeownersRemoved = append(diff.CodeownersRemoved, owner)
+		}
+	}
+	diff.SeekingNewCodeowners = new.Status.Codeowners.SeekingNew && !old.Status.Codeowners.SeekingNew
+	return diff
+}
+
+// defaultModCache returns the Go module cache, like go env GOMODCACHE does.
+func defaultModCache() string {
+	if modCache := os.Getenv("GOMODCACHE"); modCache != "" {
+		return modCache
+	}
+	if gopath := filepath.SplitList(os.Getenv("GOPATH")); len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "pkg", "mod")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// escapeModulePath escapes a module path like the module cache does, upper case letters are written as '!' and the lower case letter.
+func escapeModulePath(path string) string {
+	var builder strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			builder.WriteRune('!')
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// findModuleDir finds the directory of a component module in the module cache, e.g.
+// <modcache>/github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter@v0.122.0.
+// The module is named after the component or one of its aliases, the shallowest one wins.
+func findModuleDir(modCache, modulePath string, names []string, ver string) (string, error) {
+	root := filepath.Join(modCache, filepath.FromSlash(escapeModulePath(modulePath)))
+	var found string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		name, moduleVersion, isModule := strings.Cut(entry.Name(), "@")
+		if !isModule {
+			return nil
+		}
+		if moduleVersion == ver && slices.Contains(names, name) &&
+			(found == "" || strings.Count(path, string(filepath.Separator)) < strings.Count(found, string(filepath.Separator))) {
+			found = path
+		}
+		// Modules are not nested in the module cache
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search the module cache %s: %v", modCache, err)
+	}
+	if found == "" {
+		return "", fmt.Errorf("module %s@%s of %s not found in the module cache %s, download it with go mod download", strings.Join(names, "|"), ver, modulePath, modCache)
+	}
+	return found, nil
+}
+
+// readComponentMetadata reads the metadata.yaml of a component module.
+func readComponentMetadata(moduleDir string) (componentMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(moduleDir, "metadata.yaml"))
+	if err != nil {
+		return componentMetadata{}, err
+	}
+	var metadata componentMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return componentMetadata{}, fmt.Errorf("failed to parse %s: %v", filepath.Join(moduleDir, "metadata.yaml"), err)
+	}
+	return metadata, nil
+}
+
+// componentModuleDirs returns the module directories of a component in the old and new version.
+func componentModuleDirs(modCache string, repo githubRepo, component string, oldVersion, newVersion string, opts analysisOptions) (string, string, error) {
+	var names []string
+	for _, name := range opts.componentNames(component) {
+		// Aliases like receiver/prometheus are not module names
+		if !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	oldDir, err := findModuleDir(modCache, repo.modulePath(), names, oldVersion)
+	if err != nil {
+		return "", "", err
+	}
+	newDir, err := findModuleDir(modCache, repo.modulePath(), names, newVersion)
+	if err != nil {
+		return "", "", err
+	}
+	return oldDir, newDir, nil
+}
+
+// metadataChanges diffs the metadata.yaml of every component between the old and new version of its module in the module cache.
+// Components whose modules or metadata are missing are skipped with a warning.
+func metadataChanges(modCache string, repo githubRepo, oldVersion, newVersion string, componentsOfInterest []string, opts analysisOptions) map[string]metadataDiff {
+	changes := make(map[string]metadataDiff)
+	for _, component := range componentsOfInterest {
+		oldDir, newDir, err := componentModuleDirs(modCache, repo, component, oldVersion, newVersion, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping metadata of %s: %v\n", component, err)
+			continue
+		}
+		oldMetadata, err := readComponentMetadata(oldDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping metadata of %s: %v\n", component, err)
+			continue
+		}
+		newMetadata, err := readComponentMetadata(newDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping metadata of %s: %v\n", component, err)
+			continue
+		}
+		if diff := diffMetadata(oldMetadata, newMetadata); !diff.isEmpty() {
+			changes[component] = diff
+		}
+	}
+	return changes
+}
+
+// formatMetadataChanges formats the metadata changes of the components as a dedicated section of the report.
+func formatMetadataChanges(oldVersion, newVersion string, changes map[string]metadataDiff) string {
+	components := make([]string, 0, len(changes))
+	for component := range changes {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### Component metadata (%s to %s)\n", oldVersion, newVersion))
+	for _, component := range components {
+		diff := changes[component]
+		builder.WriteString(fmt.Sprintf("\n#### %s\n", component))
+		if len(diff.StabilityDowngrades) > 0 {
+			var downgrades []string
+			for _, change := range diff.StabilityDowngrades {
+				if change.New == "" {
+					downgrades = append(downgrades, fmt.Sprintf("%s removed (was %s)", change.Signal, change.Old))
+				} else {
+					downgrades = append(downgrades, fmt.Sprintf("%s from %s to %s", change.Signal, change.Old, change.New))
+				}
+			}
+			builder.WriteString(fmt.Sprintf("- **Stability Downgrades**: %s\n", strings.Join(downgrades, ", ")))
+		}
+		if len(diff.Deprecated) > 0 {
+			builder.WriteString(fmt.Sprintf("- **Newly Deprecated**: %s\n", strings.Join(diff.Deprecated, ", ")))
+		}
+		if len(diff.Unmaintained) > 0 {
+			builder.WriteString(fmt.Sprintf("- **Newly Unmaintained**: %s\n", strings.Join(diff.Unmaintained, ", ")))
+		}
+		// Codeowners are GitHub users, they are written as code so that nobody is notified
+		var codeowners []string
+		if len(diff.CodeownersAdded) > 0 {
+			codeowners = append(codeowners, "added "+codeList(diff.CodeownersAdded))
+		}
+		if len(diff.CodeownersRemoved) > 0 {
+			codeowners = append(codeowners, "removed "+codeList(diff.CodeownersRemoved))
+		}
+		if diff.SeekingNewCodeowners {
+			codeowners = append(codeowners, "seeking new codeowners")
+		}
+		if len(codeowners) > 0 {
+			builder.WriteString(fmt.Sprintf("- **Codeowners**: %s\n", strings.Join(codeowners, "; ")))
+		}
+	}
+	return builder.String()
+}
+
+// codeList writes the names as a comma-separated list of code spans.
+func codeList(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "`"+name+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
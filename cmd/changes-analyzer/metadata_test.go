@@ -0,0 +1,196 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffMetadata(t *testing.T) {
+	metadata := func(stability map[string][]string, active []string, seekingNew bool) componentMetadata {
+		return componentMetadata{Status: metadataStatus{
+			Stability:  stability,
+			Codeowners: metadataCodeowners{Active: active, SeekingNew: seekingNew},
+		}}
+	}
+	tests := []struct {
+		name string
+		old  componentMetadata
+		new  componentMetadata
+		want metadataDiff
+	}{
+		{
+			name: "unchanged",
+			old:  metadata(map[string][]string{"beta": {"traces", "logs"}}, []string{"alice"}, false),
+			new:  metadata(map[string][]string{"beta": {"logs", "traces"}}, []string{"alice"}, false),
+		},
+		{
+			name: "upgrade is not reported",
+			old:  metadata(map[string][]string{"alpha": {"metrics"}}, nil, false),
+			new:  metadata(map[string][]string{"beta": {"metrics"}}, nil, false),
+		},
+		{
+			name: "downgrades and removed signals",
+			old:  metadata(map[string][]string{"stable": {"metrics"}, "beta": {"logs"}}, nil, false),
+			new:  metadata(map[string][]string{"alpha": {"metrics"}}, nil, false),
+			want: metadataDiff{StabilityDowngrades: []stabilityChange{{Signal: "logs", Old: "beta"}, {Signal: "metrics", Old: "stable", New: "alpha"}}},
+		},
+		{
+			name: "deprecated and unmaintained",
+			old:  metadata(map[string][]string{"beta": {"metrics", "logs"}, "deprecated": {"traces"}}, nil, false),
+			new:  metadata(map[string][]string{"deprecated": {"metrics", "traces"}, "unmaintained": {"logs"}}, nil, false),
+			want: metadataDiff{Deprecated: []string{"metrics"}, Unmaintained: []string{"logs"}},
+		},
+		{
+			name: "codeowners",
+			old:  metadata(nil, []string{"alice", "bob"}, false),
+			new:  metadata(nil, []string{"bob", "carol"}, true),
+			want: metadataDiff{CodeownersAdded: []string{"carol"}, CodeownersRemoved: []string{"alice"}, SeekingNewCodeowners: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffMetadata(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffMetadata() returned %+v, but we expected %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// writeModCache writes files into a module cache and returns its path.
+func writeModCache(t *testing.T, files map[string]string) string {
+	t.Helper()
+	modCache := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(modCache, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return modCache
+}
+
+const contribModules = "github.com/open-telemetry/opentelemetry-collector-contrib/"
+
+func TestMetadataChanges(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		contribModules + "exporter/elasticsearchexporter@v0.121.0/metadata.yaml": `type: elasticsearch
+status:
+  class: exporter
+  stability:
+    beta: [traces, logs]
+    development: [metrics]
+  distributions: [contrib]
+  codeowners:
+    active: [alice, bob]
+`,
+		contribModules + "exporter/elasticsearchexporter@v0.122.0/metadata.yaml": `type: elasticsearch
+status:
+  class: exporter
+  stability:
+    beta: [logs]
+    alpha: [traces]
+    deprecated: [metrics]
+  distributions: [contrib]
+  codeowners:
+    active: [bob]
+    seeking_new: true
+`,
+		// Modules of internal packages may be named like components, the shallowest module wins
+		contribModules + "exporter/elasticsearchexporter@v0.122.0/internal/elasticsearchexporter@v0.122.0/metadata.yaml": "type: other\n",
+		contribModules + "receiver/prometheusreceiver@v0.121.0/metadata.yaml":                                            "type: prometheus\nstatus:\n  stability:\n    beta: [metrics]\n",
+		contribModules + "receiver/prometheusreceiver@v0.122.0/metadata.yaml":                                            "type: prometheus\nstatus:\n  stability:\n    beta: [metrics]\n",
+		contribModules + "receiver/otlpjsonfilereceiver@v0.121.0/metadata.yaml":                                          "type: otlpjsonfile\n",
+	})
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	components := []string{"elasticsearchexporter", "prometheusreceiver", "otlpjsonfilereceiver"}
+	changes := metadataChanges(modCache, repo, "v0.121.0", "v0.122.0", components, analysisOptions{})
+	want := map[string]metadataDiff{
+		"elasticsearchexporter": {
+			StabilityDowngrades:  []stabilityChange{{Signal: "traces", Old: "beta", New: "alpha"}},
+			Deprecated:           []string{"metrics"},
+			CodeownersRemoved:    []string{"alice"},
+			SeekingNewCodeowners: true,
+		},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("metadataChanges() returned %+v, but we expected %+v", changes, want)
+	}
+
+	wantMarkdown := "### Component metadata (v0.121.0 to v0.122.0)\n\n#### elasticsearchexporter\n" +
+		"- **Stability Downgrades**: traces from beta to alpha\n- **Newly Deprecated**: metrics\n" +
+		"- **Codeowners**: removed `alice`; seeking new codeowners\n"
+	if got := formatMetadataChanges("v0.121.0", "v0.122.0", changes); got != wantMarkdown {
+		t.Errorf("formatMetadataChanges() returned %q, but we expected %q", got, wantMarkdown)
+	}
+}
+
+func TestFindModuleDir(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		"go.opentelemetry.io/collector/receiver/otlpreceiver@v0.122.0/metadata.yaml": "type: otlp\n",
+		"github.com/!data!dog/datadog-agent/pkg/obfuscate@v0.64.0/go.mod":            "module github.com/DataDog/datadog-agent/pkg/obfuscate\n",
+	})
+	tests := []struct {
+		modulePath string
+		names      []string
+		version    string
+		want       string
+		wantErr    string
+	}{
+		{
+			modulePath: "go.opentelemetry.io/collector",
+			names:      []string{"otlpreceiver"},
+			version:    "v0.122.0",
+			want:       "go.opentelemetry.io/collector/receiver/otlpreceiver@v0.122.0",
+		},
+		{
+			modulePath: "github.com/DataDog/datadog-agent",
+			names:      []string{"obfuscate"},
+			version:    "v0.64.0",
+			want:       "github.com/!data!dog/datadog-agent/pkg/obfuscate@v0.64.0",
+		},
+		{
+			modulePath: "go.opentelemetry.io/collector",
+			names:      []string{"otlpreceiver"},
+			version:    "v0.121.0",
+			wantErr:    "module otlpreceiver@v0.121.0 of go.opentelemetry.io/collector not found in the module cache",
+		},
+		{
+			modulePath: "github.com/open-telemetry/opentelemetry-collector-contrib",
+			names:      []string{"otlpreceiver"},
+			version:    "v0.122.0",
+			wantErr:    "not found in the module cache",
+		},
+	}
+	for _, tt := range tests {
+		got, err := findModuleDir(modCache, tt.modulePath, tt.names, tt.version)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("findModuleDir(%s, %v, %s) returned error %v, but we expected %q", tt.modulePath, tt.names, tt.version, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != filepath.Join(modCache, filepath.FromSlash(tt.want)) {
+			t.Errorf("findModuleDir(%s, %v, %s) returned %q, %v, but we expected %q", tt.modulePath, tt.names, tt.version, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// latestKeyword selects the newest release of the series.
+	latestKeyword = "latest"
+	// latestMinorKeyword selects the newest minor release of the series, i.e. the newest release with patch version 0.
+	latestMinorKeyword = "latest-minor"
+)
+
+// versionRange selects the analyzed releases of a series, either by the first and last release,
+// or by a version range expression and publication dates.
+type versionRange struct {
+	// Old and New are the first and last analyzed release, both included. They are tags, with or without the
+	// sub-module prefix, or one of the keywords latest and latest-minor. New defaults to latest.
+	Old string
+	New string
+	// Constraints is a version range expression, e.g. ">=0.119.0,<0.123.0".
+	Constraints string
+	// Since and Until select the releases published between the two dates, both included. Zero dates are unbounded.
+	Since time.Time
+	Until time.Time
+	// Chloggen is a local clone of the repository, whose pending .chloggen entries are analyzed as its next release.
+	Chloggen string
+}
+
+// byReleases reports whether the range is given by its first and last release.
+func (r versionRange) byReleases() bool {
+	return r.Old != "" || r.New != ""
+}
+
+// String describes the range for error messages.
+func (r versionRange) String() string {
+	if r.Chloggen != "" {
+		return "pending in " + r.Chloggen
+	}
+	if r.byReleases() {
+		return fmt.Sprintf("between %s and %s", r.Old, r.New)
+	}
+	var parts []string
+	if r.Constraints != "" {
+		parts = append(parts, "matching "+r.Constraints)
+	}
+	if !r.Since.IsZero() {
+		parts = append(parts, "published since "+r.Since.Format(time.DateOnly))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "published until "+r.Until.Format(time.DateOnly))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// resolvedRange is a version range resolved against the releases of a repository.
+type resolvedRange struct {
+	// Old and New are the tags of the first and last release of the range, without the sub-module prefix.
+	Old      string
+	New      string
+	Releases []release
+}
+
+// resolveReleases lists the releases of the repository and selects the ones in the range.
+// Pending changes of a local clone are resolved without listing the releases.
+func resolveReleases(rng versionRange, repo githubRepo, filter releaseFilter) (resolvedRange, error) {
+	if rng.Chloggen != "" {
+		rel, err := pendingRelease(rng.Chloggen)
+		if err != nil {
+			return resolvedRange{}, err
+		}
+		return resolvedRange{Old: rel.PreviousTag, New: rel.Tag, Releases: []release{rel}}, nil
+	}
+	allReleases, err := listReleases(repo)
+	if err != nil {
+		return resolvedRange{}, err
+	}
+	series := releaseSeries(allReleases, filter)
+	if len(series) == 0 {
+		return resolvedRange{}, fmt.Errorf("no releases of %s found", repo)
+	}
+
+	var releases []release
+	if rng.byReleases() {
+		releases, err = rng.selectBetween(series, allReleases, repo, filter)
+	} else {
+		releases, err = rng.selectMatching(series)
+	}
+	if err != nil {
+		return resolvedRange{}, err
+	}
+	if len(releases) == 0 {
+		return resolvedRange{}, fmt.Errorf("no releases of %s found %s", repo, rng)
+	}
+	return resolvedRange{
+		Old:      seriesTag(releases[0], filter),
+		New:      seriesTag(releases[len(releases)-1], filter),
+		Releases: releases,
+	}, nil
+}
+
+// seriesTag returns the tag of a release without the sub-module prefix.
+func seriesTag(rel release, filter releaseFilter) string {
+	tag, _ := filter.seriesVersion(rel.Tag)
+	return tag
+}
+
+// selectBetween selects the releases from Old to New, which both have to exist.
+func (r versionRange) selectBetween(series []release, allReleases []githubRelease, repo githubRepo, filter releaseFilter) ([]release, error) {
+	if r.Old == "" {
+		return nil, fmt.Errorf("old version is required with new version %s", r.New)
+	}
+	newVersion := r.New
+	if newVersion == "" {
+		newVersion = latestKeyword
+	}
+	oldIndex, err := findRelease(r.Old, series, allReleases, repo, filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid old version: %v", err)
+	}
+	newIndex, err := findRelease(newVersion, series, allReleases, repo, filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid new version: %v", err)
+	}
+	if oldIndex > newIndex {
+		return nil, fmt.Errorf("reversed range: old version %s (%s) is newer than new version %s (%s), swap --old and --new",
+			r.Old, series[oldIndex].Tag, newVersion, series[newIndex].Tag)
+	}
+	return series[oldIndex : newIndex+1], nil
+}
+
+// findRelease returns the index of the release of the series given by a tag or keyword.
+// Errors explain why a tag is not found and name the nearest releases.
+func findRelease(spec string, series []release, allReleases []githubRelease, repo githubRepo, filter releaseFilter) (int, error) {
+	switch spec {
+	case latestKeyword:
+		return len(series) - 1, nil
+	case latestMinorKeyword:
+		for i := len(series) - 1; i >= 0; i-- {
+			if segments := series[i].Version.Segments(); len(segments) > 2 && segments[2] == 0 {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("no minor release of %s found", repo)
+	}
+
+	// Versions may be given with or without the sub-module prefix
+	ver, err := parseVersion(strings.TrimPrefix(spec, filter.TagPrefix+"/"))
+	if err != nil {
+		return 0, fmt.Errorf("%s is neither a version nor one of %s, %s: %v", spec, latestKeyword, latestMinorKeyword, err)
+	}
+	for i, rel := range series {
+		if rel.Version.Equal(ver) {
+			return i, nil
+		}
+	}
+
+	// Explain releases that exist, but are not part of the analyzed series
+	if !filter.IncludePrereleases {
+		for _, rel := range releaseSeries(allReleases, releaseFilter{IncludePrereleases: true, TagPrefix: filter.TagPrefix}) {
+			if rel.Version.Equal(ver) {
+				return 0, fmt.Errorf("release %s of %s is a pre-release, use --prerelease to analyze pre-releases", rel.Tag, repo)
+			}
+		}
+	}
+	return 0, fmt.Errorf("release %s of %s not found, %s", spec, repo, nearestReleases(ver, series))
+}
+
+// nearestReleases describes the releases of the series around a version that does not exist.
+func nearestReleases(ver *version.Version, series []release) string {
+	first, last := series[0], series[len(series)-1]
+	switch {
+	case ver.GreaterThan(last.Version):
+		return fmt.Sprintf("the latest release is %s", last.Tag)
+	case ver.LessThan(first.Version):
+		return fmt.Sprintf("the oldest release is %s", first.Tag)
+	}
+	for i := 1; i < len(series); i++ {
+		if series[i].Version.GreaterThan(ver) {
+			return fmt.Sprintf("the nearest releases are %s and %s", series[i-1].Tag, series[i].Tag)
+		}
+	}
+	return fmt.Sprintf("the latest release is %s", last.Tag)
+}
+
+// selectMatching selects the releases matching the version range expression and the publication dates.
+func (r versionRange) selectMatching(series []release) ([]release, error) {
+	var constraints version.Constraints
+	if r.Constraints != "" {
+		var err error
+		constraints, err = version.NewConstraint(r.Constraints)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %v", r.Constraints, err)
+		}
+	}
+	byDate := !r.Since.IsZero() || !r.Until.IsZero()
+
+	var releases []release
+	for _, rel := range series {
+		if constraints != nil && !constraints.Check(rel.Version) {
+			continue
+		}
+		if byDate {
+			if rel.PublishedAt.IsZero() {
+				return nil, fmt.Errorf("release %s has no publication date, date ranges need one for every release", rel.Tag)
+			}
+			if !r.Since.IsZero() && rel.PublishedAt.Before(r.Since) {
+				continue
+			}
+			// Until includes the whole day
+			if !r.Until.IsZero() && !rel.PublishedAt.Before(r.Until.AddDate(0, 0, 1)) {
+				continue
+			}
+		}
+		releases = append(releases, rel)
+	}
+	return releases, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withReleaseListing serves the release listing of opentelemetry-collector-contrib on two pages.
+func withReleaseListing(t *testing.T) {
+	t.Helper()
+	const firstPage = "https://api.github.com/repos/open-telemetry/opentelemetry-collector-contrib/releases?per_page=100"
+	const secondPage = firstPage + "&page=2"
+	pages := map[string]struct {
+		body string
+		link string
+	}{
+		firstPage: {`[
+			{"tag_name":"v0.124.0-rc.1","prerelease":true,"published_at":"2026-09-10T10:00:00Z"},
+			{"tag_name":"v0.123.1","prerelease":false,"published_at":"2026-08-20T10:00:00Z"},
+			{"tag_name":"v0.123.0","prerelease":false,"published_at":"2026-08-05T10:00:00Z"},
+			{"tag_name":"v0.122.1","prerelease":false,"published_at":"2026-08-01T23:30:00Z"}
+		]`, `<` + secondPage + `>; rel="next"`},
+		secondPage: {`[
+			{"tag_name":"v0.122.0","prerelease":false,"published_at":"2026-07-20T10:00:00Z"},
+			{"tag_name":"v0.121.0","prerelease":false,"published_at":"2026-07-01T10:00:00Z"},
+			{"tag_name":"v0.119.0","prerelease":false,"published_at":"2026-06-01T10:00:00Z"}
+		]`, `<` + firstPage + `>; rel="first"`},
+	}
+	originalTransport := client.Transport
+	client.Transport = funcTransport(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return statusResponse(http.StatusNotFound, nil)()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Link": []string{page.link}}, Body: io.NopCloser(strings.NewReader(page.body))}, nil
+	})
+	t.Cleanup(func() { client.Transport = originalTransport })
+}
+
+func TestResolveReleases(t *testing.T) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			t.Fatalf("invalid date %s: %v", value, err)
+		}
+		return parsed
+	}
+	tests := []struct {
+		name    string
+		rng     versionRange
+		filter  releaseFilter
+		want    []string
+		wantOld string
+		wantNew string
+		errMsg  string
+	}{
+		{
+			name:    "old and new from all pages",
+			rng:     versionRange{Old: "v0.119.0", New: "0.122.0"},
+			want:    []string{"v0.119.0", "v0.121.0", "v0.122.0"},
+			wantOld: "v0.119.0",
+			wantNew: "v0.122.0",
+		},
+		{
+			name: "new defaults to latest",
+			rng:  versionRange{Old: "v0.123.0"},
+			want: []string{"v0.123.0", "v0.123.1"},
+		},
+		{
+			name:   "latest includes pre-releases when asked",
+			rng:    versionRange{Old: "v0.123.1", New: "latest"},
+			filter: releaseFilter{IncludePrereleases: true},
+			want:   []string{"v0.123.1", "v0.124.0-rc.1"},
+		},
+		{
+			name: "latest minor",
+			rng:  versionRange{Old: "latest-minor", New: "latest"},
+			want: []string{"v0.123.0", "v0.123.1"},
+		},
+		{
+			name: "range expression",
+			rng:  versionRange{Constraints: ">=0.119.0,<0.123.0"},
+			want: []string{"v0.119.0", "v0.121.0", "v0.122.0", "v0.122.1"},
+		},
+		{
+			name:    "since a date",
+			rng:     versionRange{Since: date("2026-08-01")},
+			want:    []string{"v0.122.1", "v0.123.0", "v0.123.1"},
+			wantOld: "v0.122.1",
+			wantNew: "v0.123.1",
+		},
+		{
+			name: "date range includes the last day",
+			rng:  versionRange{Since: date("2026-07-01"), Until: date("2026-08-01")},
+			want: []string{"v0.121.0", "v0.122.0", "v0.122.1"},
+		},
+		{
+			name: "range expression and dates",
+			rng:  versionRange{Constraints: "~>0.122.0", Since: date("2026-07-21")},
+			want: []string{"v0.122.1"},
+		},
+		{
+			name:   "reversed range",
+			rng:    versionRange{Old: "v0.123.0", New: "v0.121.0"},
+			errMsg: "reversed range: old version v0.123.0 (v0.123.0) is newer than new version v0.121.0 (v0.121.0), swap --old and --new",
+		},
+		{
+			name:   "missing new version",
+			rng:    versionRange{Old: "v0.121.0", New: "v0.125.0"},
+			errMsg: "invalid new version: release v0.125.0 of open-telemetry/opentelemetry-collector-contrib not found, the latest release is v0.123.1",
+		},
+		{
+			name:   "missing old version",
+			rng:    versionRange{Old: "v0.120.0", New: "v0.121.0"},
+			errMsg: "invalid old version: release v0.120.0 of open-telemetry/opentelemetry-collector-contrib not found, the nearest releases are v0.119.0 and v0.121.0",
+		},
+		{
+			name:   "version older than all releases",
+			rng:    versionRange{Old: "v0.100.0", New: "v0.121.0"},
+			errMsg: "the oldest release is v0.119.0",
+		},
+		{
+			name:   "pre-release without --prerelease",
+			rng:    versionRange{Old: "v0.123.0", New: "v0.124.0-rc.1"},
+			errMsg: "release v0.124.0-rc.1 of open-telemetry/opentelemetry-collector-contrib is a pre-release, use --prerelease to analyze pre-releases",
+		},
+		{
+			name:   "not a version",
+			rng:    versionRange{Old: "newest"},
+			errMsg: "newest is neither a version nor one of latest, latest-minor",
+		},
+		{
+			name:   "no release in range",
+			rng:    versionRange{Constraints: ">=0.200.0"},
+			errMsg: "no releases of open-telemetry/opentelemetry-collector-contrib found matching >=0.200.0",
+		},
+		{
+			name:   "invalid range expression",
+			rng:    versionRange{Constraints: "0.119.0..0.123.0"},
+			errMsg: `invalid version range "0.119.0..0.123.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withReleaseListing(t)
+			resolved, err := resolveReleases(tt.rng, mustParseRepo(t, "opentelemetry-collector-contrib"), tt.filter)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("resolveReleases() error = %v, but we expected error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveReleases() error = %v", err)
+			}
+			var got []string
+			for _, rel := range resolved.Releases {
+				got = append(got, rel.Tag)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("resolveReleases() returned %v, but we expected %v", got, tt.want)
+			}
+			if tt.wantOld != "" && (resolved.Old != tt.wantOld || resolved.New != tt.wantNew) {
+				t.Errorf("resolveReleases() resolved %s to %s, but we expected %s to %s", resolved.Old, resolved.New, tt.wantOld, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestResolveReleasesWithoutPublicationDates(t *testing.T) {
+	changelogURL := "https://github.com/solarwinds/solarwinds-otel-collector-contrib/raw/HEAD/CHANGELOG.md"
+	originalTransport := client.Transport
+	client.Transport = funcTransport(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != changelogURL {
+			return statusResponse(http.StatusNotFound, nil)()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(solarwindsChangelog))}, nil
+	})
+	t.Cleanup(func() { client.Transport = originalTransport })
+
+	repo := mustParseRepo(t, "solarwinds/solarwinds-otel-collector-contrib/CHANGELOG.md")
+	_, err := resolveReleases(versionRange{Since: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)}, repo, releaseFilter{})
+	if err == nil || !strings.Contains(err.Error(), "release v0.152.0 has no publication date") {
+		t.Errorf("resolveReleases() error = %v, but we expected a missing publication date", err)
+	}
+}
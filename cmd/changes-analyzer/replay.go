@@ -0,0 +1,163 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"unicode/utf8"
+)
+
+// fixture is the file format shared by recordingTransport and replayTransport.
+type fixture struct {
+	Exchanges []exchange `json:"exchanges"`
+}
+
+// exchange is a single recorded HTTP request and its response.
+// Bodies that are valid UTF-8 are stored as text to keep fixtures readable, other bodies are base64 encoded.
+type exchange struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+func (e exchange) key() string {
+	return e.Method + " " + e.URL
+}
+
+func (e exchange) body() ([]byte, error) {
+	if e.BodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(e.BodyBase64)
+	}
+	return []byte(e.Body), nil
+}
+
+// recordingTransport passes requests to the next transport and records every exchange.
+type recordingTransport struct {
+	next      http.RoundTripper
+	mu        sync.Mutex
+	exchanges []exchange
+}
+
+func newRecordingTransport(next http.RoundTripper) *recordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{next: next}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for recording: %v", err)
+	}
+
+	recorded := exchange{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	t.mu.Lock()
+	t.exchanges = append(t.exchanges, recorded)
+	t.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// save writes all recorded exchanges into the fixture file.
+func (t *recordingTransport) save(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, err := json.MarshalIndent(fixture{Exchanges: t.exchanges}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture %s: %v", path, err)
+	}
+	return nil
+}
+
+// replayTransport serves responses recorded by recordingTransport without any network access.
+// Exchanges for the same request are served in recorded order, the last one is repeated afterwards.
+type replayTransport struct {
+	mu        sync.Mutex
+	exchanges map[string][]exchange
+}
+
+func newReplayTransport(path string) (*replayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %v", path, err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %v", path, err)
+	}
+	t := &replayTransport{exchanges: make(map[string][]exchange)}
+	for _, e := range f.Exchanges {
+		t.exchanges[e.key()] = append(t.exchanges[e.key()], e)
+	}
+	return t, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String()
+	t.mu.Lock()
+	queue := t.exchanges[key]
+	if len(queue) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+	e := queue[0]
+	if len(queue) > 1 {
+		t.exchanges[key] = queue[1:]
+	}
+	t.mu.Unlock()
+
+	body, err := e.body()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recorded body for %s: %v", key, err)
+	}
+	return &http.Response{
+		StatusCode:    e.StatusCode,
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// recordFixtures re-records the fixtures in testdata from GitHub, run go test -run TestRecordFixtures -record.
+var recordFixtures = flag.Bool("record", false, "record the fixtures in testdata from GitHub")
+
+// recordedFixtures are the fixtures in testdata recorded from GitHub, with the real runs they capture.
+// Fixtures prefixed with synthetic- are written by hand to cover cases of the release notes and are not recorded.
+var recordedFixtures = map[string][]string{
+	"contrib-v0.121.0-v0.122.0.json": {"report", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0", "--components", "elasticsearchexporter"},
+}
+
+// TestRecordFixtures records the fixtures of the regression tests with the real runs they replay.
+func TestRecordFixtures(t *testing.T) {
+	if !*recordFixtures {
+		t.Skip("recording needs network access, run with -record")
+	}
+	for name, args := range recordedFixtures {
+		// A failed run keeps the previous fixture
+		recorded := filepath.Join(t.TempDir(), name)
+		var stderr bytes.Buffer
+		if code := run(append(args, "--no-cache", "--record", recorded), io.Discard, &stderr); code != 0 {
+			t.Errorf("recording %s failed with %d:\n%s", name, code, stderr.String())
+			continue
+		}
+		data, err := os.ReadFile(recorded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join("testdata", name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestReplayRecordedFixtures replays the recorded runs, so that real release notes keep being analyzed without errors.
+func TestReplayRecordedFixtures(t *testing.T) {
+	for name, args := range recordedFixtures {
+		t.Run(name, func(t *testing.T) {
+			fixture := filepath.Join("testdata", name)
+			if _, err := os.Stat(fixture); err != nil {
+				t.Skipf("%s is not recorded yet, run go test -run TestRecordFixtures -record", name)
+			}
+			var stdout, stderr bytes.Buffer
+			if code := run(append(args, "--replay", fixture), &stdout, &stderr); code != 0 || stdout.Len() == 0 {
+				t.Errorf("replaying %s = %d, stderr:\n%s", name, code, stderr.String())
+			}
+		})
+	}
+}
+
+// withReplay serves all requests of the test from the given fixture in testdata.
+func withReplay(t *testing.T, name string) {
+	t.Helper()
+	replayer, err := newReplayTransport(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("newReplayTransport() error = %v", err)
+	}
+	originalTransport := client.Transport
+	client.Transport = replayer
+	t.Cleanup(func() { client.Transport = originalTransport })
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	binary := []byte{0xff, 0xfe, 0x00, 'x'}
+	bodies := map[string][]byte{
+		"https://example.com/text":   []byte("plain text\n"),
+		"https://example.com/binary": binary,
+	}
+	recorder := newRecordingTransport(funcTransport(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": []string{`"abc"`}},
+			Body:       io.NopCloser(bytes.NewReader(bodies[req.URL.String()])),
+		}, nil
+	}))
+
+	for url, want := range bodies {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		resp, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("recording RoundTrip(%s) error = %v", url, err)
+		}
+		got, _ := io.ReadAll(resp.Body)
+		if !bytes.Equal(got, want) {
+			t.Errorf("recording changed the body of %s: got %q, want %q", url, got, want)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	replayer, err := newReplayTransport(path)
+	if err != nil {
+		t.Fatalf("newReplayTransport() error = %v", err)
+	}
+	for url, want := range bodies {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		resp, err := replayer.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("replay RoundTrip(%s) error = %v", url, err)
+		}
+		got, _ := io.ReadAll(resp.Body)
+		if !bytes.Equal(got, want) {
+			t.Errorf("replayed body of %s = %q, but we expected %q", url, got, want)
+		}
+		if etag := resp.Header.Get("Etag"); etag != `"abc"` {
+			t.Errorf("replayed Etag of %s = %q, but we expected %q", url, etag, `"abc"`)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/missing", nil)
+	if _, err := replayer.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no recorded response for GET https://example.com/missing") {
+		t.Errorf("replay RoundTrip() error = %v, but we expected a missing recording error", err)
+	}
+}
+
+func TestGetMessageReplay(t *testing.T) {
+	withReplay(t, "synthetic-contrib-v0.121.0-v0.122.0.json")
+
+	message, err := getMessage("v0.121.0", "v0.122.0", []string{"elasticsearchexporter"}, mustParseRepo(t, "opentelemetry-collector-contrib"), analysisOptions{})
+	if err != nil {
+		t.Fatalf("getMessage failed: %v", err)
+	}
+	for _, want := range []string{
+		"#### elasticsearchexporter\n",
+		"- **Breaking Changes**:\n  - 0.122.0: `elasticsearchexporter`: Dynamically route documents",
+		"- **Deprecations**:\n  - 0.122.0: `elasticsearchexporter`: Deprecate",
+		"- **Enhancements**:\n  - 0.121.0: `elasticsearchexporter`: Add",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("getMessage result does not contain %q:\n%s", want, message)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// formatEntryText formats the text of an entry for posting on GitHub. Besides the code formatting of formatDescription,
+// upstream content is sanitized: mentions are neutralized so that nobody is notified, HTML is escaped
+// and references to issues and pull requests are written as explicit links.
+func formatEntryText(repo githubRepo, text string) string {
+	tokens := tokenizeDescription(text)
+	var builder strings.Builder
+	for i, t := range tokens {
+		switch t.Kind {
+		case tokenText:
+			builder.WriteString(escapeHTML(t.Text))
+		case tokenMention:
+			// Mentions are not resolved in code spans, so the text stays readable without pinging anyone
+			builder.WriteString("`" + t.Text + "`")
+		case tokenReference:
+			builder.WriteString(referenceLink(repo, t.Text, referenceKindHint(tokens[:i])))
+		case tokenLink:
+			builder.WriteString(issueURLLink(repo, escapeLinkText(t.Text)))
+		default:
+			builder.WriteString(formatWord(t))
+		}
+	}
+	return builder.String()
+}
+
+// htmlEscaper escapes the characters that start HTML tags and entities. Other characters keep their markdown meaning.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;")
+
+func escapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+// escapeLinkText escapes the HTML in the text of a markdown link, e.g. [<b>docs</b>](https://...).
+// Autolinks and bare URLs have no text and are returned as they are.
+func escapeLinkText(link string) string {
+	if !strings.HasPrefix(link, "[") {
+		return link
+	}
+	end := strings.Index(link, "](")
+	return "[" + escapeHTML(link[1:end]) + link[end:]
+}
+
+// referenceKind tells whether a reference points at an issue or a pull request.
+type referenceKind int
+
+const (
+	// referenceUnknown is linked as a pull request, GitHub redirects to the issue when needed.
+	referenceUnknown referenceKind = iota
+	referenceIssue
+	referencePull
+)
+
+// issueWords and pullWords are the words preceding references that tell their kind, e.g. "fixes #123" or "PR #123".
+var issueWords = map[string]bool{
+	"issue": true, "issues": true, "bug": true,
+	"fix": true, "fixes": true, "fixed": true, "close": true, "closes": true, "closed": true, "resolve": true, "resolves": true, "resolved": true,
+}
+var pullWords = map[string]bool{"pr": true, "prs": true, "pull": true, "request": true, "requests": true}
+
+// referenceKindHint derives the kind of a reference from the word before it. A list of references,
+// e.g. "issues #1, #2", shares the word before the first one.
+func referenceKindHint(preceding []token) referenceKind {
+	for i := len(preceding) - 1; i >= 0; i-- {
+		switch t := preceding[i]; t.Kind {
+		case tokenReference:
+			continue
+		case tokenText:
+			if strings.Trim(t.Text, " ,(") != "" {
+				return referenceUnknown
+			}
+			continue
+		case tokenWord:
+			word := strings.ToLower(t.Text)
+			if word == "and" || word == "or" {
+				continue
+			}
+			if issueWords[word] {
+				return referenceIssue
+			}
+			if pullWords[word] {
+				return referencePull
+			}
+		}
+		return referenceUnknown
+	}
+	return referenceUnknown
+}
+
+// referenceLink writes a reference, e.g. #123 or owner/repo#123, as a link. References without a repository belong to the analyzed repository.
+func referenceLink(repo githubRepo, reference string, kind referenceKind) string {
+	target := repo
+	repoPart, number, _ := strings.Cut(reference, "#")
+	if owner, name, ok := strings.Cut(repoPart, "/"); ok {
+		target = repo.sibling(owner, name)
+	}
+	url := target.pullURL(number)
+	if kind == referenceIssue {
+		url = target.issueURL(number)
+	}
+	return fmt.Sprintf("[%s](%s)", reference, url)
+}
+
+// issueURLPattern matches the web page of an issue or a pull request.
+var issueURLPattern = regexp.MustCompile(`^(https?://[^/\s]+)/([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)/(issues|pull)/(\d+)/?$`)
+
+// issueURLLink writes a bare URL of an issue or pull request on the host of the repository as a link labelled with its reference,
+// e.g. [#123](...) or [owner/repo#123](...). Other links are returned as they are.
+func issueURLLink(repo githubRepo, link string) string {
+	match := issueURLPattern.FindStringSubmatch(link)
+	if match == nil || match[1] != repo.WebBaseURL {
+		return link
+	}
+	label := "#" + match[5]
+	if match[2] != repo.Owner || match[3] != repo.Name {
+		label = match[2] + "/" + match[3] + label
+	}
+	return fmt.Sprintf("[%s](%s)", label, link)
+}
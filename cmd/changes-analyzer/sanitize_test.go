@@ -0,0 +1,110 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestSanitizeEntryText(t *testing.T) {
+	const pull = "https://github.com/open-telemetry/opentelemetry-collector/pull/"
+	const issues = "https://github.com/open-telemetry/opentelemetry-collector/issues/"
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "mentions",
+			text: "Thanks @some-user and @open-telemetry/collector-approvers, mail me@example.com",
+			want: "Thanks `@some-user` and `@open-telemetry/collector-approvers`, mail me@example.com",
+		},
+		{
+			name: "mentions in code spans",
+			text: "Use the `@timestamp` field",
+			want: "Use the `@timestamp` field",
+		},
+		{
+			name: "HTML",
+			text: "Drop <script>alert(1)</script> & <details> tags, but keep <https://example.com> and a > b",
+			want: "Drop &lt;script>alert(1)&lt;/script> &amp; &lt;details> tags, but keep <https://example.com> and a > b",
+		},
+		{
+			name: "HTML in link text",
+			text: "Read [<b>docs</b> & more](https://example.com/a?b=1&c=2)",
+			want: "Read [&lt;b>docs&lt;/b> &amp; more](https://example.com/a?b=1&c=2)",
+		},
+		{
+			name: "HTML in code spans",
+			text: "Parse `<br>` tags",
+			want: "Parse `<br>` tags",
+		},
+		{
+			name: "cross-repository reference",
+			text: "Follow open-telemetry/opentelemetry-go#5000",
+			want: "Follow [open-telemetry/opentelemetry-go#5000](https://github.com/open-telemetry/opentelemetry-go/pull/5000)",
+		},
+		{
+			name: "issue wording",
+			text: "Fixes #10, #11 and #12 (PR #13)",
+			want: "Fixes [#10](" + issues + "10), [#11](" + issues + "11) and [#12](" + issues + "12) (PR [#13](" + pull + "13))",
+		},
+		{
+			name: "cross-repository issue",
+			text: "See issue open-telemetry/opentelemetry-go#5000",
+			want: "See issue [open-telemetry/opentelemetry-go#5000](https://github.com/open-telemetry/opentelemetry-go/issues/5000)",
+		},
+		{
+			name: "issue and pull request URLs",
+			text: "See https://github.com/open-telemetry/opentelemetry-collector/issues/7 and https://github.com/open-telemetry/opentelemetry-go/pull/8.",
+			want: "See [#7](" + issues + "7) and [open-telemetry/opentelemetry-go#8](https://github.com/open-telemetry/opentelemetry-go/pull/8).",
+		},
+		{
+			name: "other URLs",
+			text: "See https://example.com/a/b/issues/7 and https://github.com/open-telemetry/opentelemetry-collector/issues/7#issuecomment-1",
+			want: "See https://example.com/a/b/issues/7 and https://github.com/open-telemetry/opentelemetry-collector/issues/7#issuecomment-1",
+		},
+	}
+	repo := mustParseRepo(t, "opentelemetry-collector")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEntryText(repo, tt.text); got != tt.want {
+				t.Errorf("formatEntryText(%q) returned %q, but we expected %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceKindFromHTML(t *testing.T) {
+	html := `<ul><li>otlpreceiver: Fix <a class="issue-link" data-hovercard-type="issue" href="https://github.com/open-telemetry/opentelemetry-collector/issues/1">#1</a>
+		in <a class="issue-link" data-hovercard-type="pull_request" href="https://github.com/open-telemetry/opentelemetry-collector/pull/2">#2</a>
+		(<a href="https://github.com/open-telemetry/opentelemetry-collector/issues/3">#3</a>),
+		thanks <a class="user-mention" data-hovercard-type="user" href="https://github.com/someone">@someone</a></li></ul>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to parse HTML: %v", err)
+	}
+	text := listItemMarkdown(doc.Find("li"))
+	got := formatEntryText(mustParseRepo(t, "opentelemetry-collector"), text)
+	want := "otlpreceiver: Fix [#1](https://github.com/open-telemetry/opentelemetry-collector/issues/1)" +
+		" in [#2](https://github.com/open-telemetry/opentelemetry-collector/pull/2)" +
+		" ([#3](https://github.com/open-telemetry/opentelemetry-collector/pull/3)), thanks `@someone`"
+	if got != want {
+		t.Errorf("formatEntryText() returned %q, but we expected %q", got, want)
+	}
+}
@@ -0,0 +1,470 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	sectionMetrics            = "metrics"
+	sectionResourceAttributes = "resource_attributes"
+	sectionEvents             = "events"
+)
+
+// telemetrySections lists the sections of metadata.yaml that define the telemetry of a component, in the order they are reported.
+var telemetrySections = []string{sectionMetrics, sectionResourceAttributes, sectionEvents}
+
+// telemetryMetadata is the part of a metadata.yaml that defines the telemetry of a component or of one of its scrapers.
+type telemetryMetadata struct {
+	// Type is the type of the component, or the name of the scraper, e.g. process of internal/scraper/processscraper.
+	Type   string `yaml:"type"`
+	Status struct {
+		// Class is the class of the component, e.g. receiver, scrapers have none.
+		Class string `yaml:"class"`
+	} `yaml:"status"`
+	Metrics            map[string]telemetryDefinition `yaml:"metrics"`
+	ResourceAttributes map[string]telemetryDefinition `yaml:"resource_attributes"`
+	Events             map[string]telemetryDefinition `yaml:"events"`
+}
+
+func (m telemetryMetadata) section(name string) map[string]telemetryDefinition {
+	switch name {
+	case sectionMetrics:
+		return m.Metrics
+	case sectionResourceAttributes:
+		return m.ResourceAttributes
+	default:
+		return m.Events
+	}
+}
+
+// telemetryDefinition is a metric, resource attribute or event of metadata.yaml.
+type telemetryDefinition struct {
+	Enabled     bool   `yaml:"enabled"`
+	Description string `yaml:"description"`
+	Unit        string `yaml:"unit"`
+	// Type is the type of a resource attribute.
+	Type      string      `yaml:"type"`
+	Sum       *metricData `yaml:"sum"`
+	Gauge     *metricData `yaml:"gauge"`
+	Histogram *metricData `yaml:"histogram"`
+}
+
+// metricData is the data type of a metric, gauges have no monotonicity nor temporality.
+type metricData struct {
+	ValueType              string `yaml:"value_type"`
+	Monotonic              bool   `yaml:"monotonic"`
+	AggregationTemporality string `yaml:"aggregation_temporality"`
+}
+
+// dataType describes the type of the definition, e.g. "sum (int, monotonic, cumulative)" for a metric or "string" for a resource attribute.
+func (d telemetryDefinition) dataType() string {
+	describe := func(kind string, data *metricData) string {
+		var details []string
+		if data.ValueType != "" {
+			details = append(details, data.ValueType)
+		}
+		if data.Monotonic {
+			details = append(details, "monotonic")
+		}
+		if data.AggregationTemporality != "" {
+			details = append(details, data.AggregationTemporality)
+		}
+		if len(details) == 0 {
+			return kind
+		}
+		return fmt.Sprintf("%s (%s)", kind, strings.Join(details, ", "))
+	}
+	switch {
+	case d.Sum != nil:
+		return describe("sum", d.Sum)
+	case d.Gauge != nil:
+		return describe("gauge", d.Gauge)
+	case d.Histogram != nil:
+		return describe("histogram", d.Histogram)
+	}
+	return d.Type
+}
+
+const (
+	telemetryAdded   = "added"
+	telemetryRemoved = "removed"
+	telemetryRenamed = "renamed"
+	telemetryChanged = "changed"
+)
+
+// telemetryChange is an added, removed, renamed or changed metric, resource attribute or event.
+type telemetryChange struct {
+	Section string `json:"section"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	// RenamedTo is the new name of a renamed definition.
+	RenamedTo string `json:"renamed_to,omitempty"`
+	// Details describe the changes of a changed or renamed definition, e.g. "unit from By to {bytes}".
+	Details []string `json:"details,omitempty"`
+	// References are the lines of our example configs that refer to the name, e.g. mysql/config.yaml:29.
+	References []string `json:"references,omitempty"`
+}
+
+// componentTelemetryDiff holds the telemetry changes of a metadata.yaml of a component.
+type componentTelemetryDiff struct {
+	Component string `json:"component"`
+	// Metadata is the path of the metadata.yaml in the module, scrapers of a receiver have their own, e.g. internal/scraper/processscraper/metadata.yaml.
+	Metadata string            `json:"metadata"`
+	Changes  []telemetryChange `json:"changes"`
+}
+
+// readTelemetryMetadata reads all metadata.yaml files of a module, keyed by their path in the module.
+func readTelemetryMetadata(moduleDir string) (map[string]telemetryMetadata, error) {
+	files := make(map[string]telemetryMetadata)
+	err := filepath.WalkDir(moduleDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "metadata.yaml" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var metadata telemetryMetadata
+		if err := yaml.Unmarshal(data, &metadata); err != nil {
+			return fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		rel, err := filepath.Rel(moduleDir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = metadata
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// definitionChanges describes the differences of two definitions that matter to users: unit, type and the enabled default.
+func definitionChanges(old, new telemetryDefinition) []string {
+	var details []string
+	if old.Unit != new.Unit {
+		details = append(details, fmt.Sprintf("unit from `%s` to `%s`", old.Unit, new.Unit))
+	}
+	if oldType, newType := old.dataType(), new.dataType(); oldType != newType {
+		details = append(details, fmt.Sprintf("type from %s to %s", oldType, newType))
+	}
+	if old.Enabled != new.Enabled {
+		details = append(details, fmt.Sprintf("enabled by default from %t to %t", old.Enabled, new.Enabled))
+	}
+	return details
+}
+
+// diffTelemetrySection compares a section of the old and new metadata. A removed and an added definition with the same description are a rename.
+func diffTelemetrySection(section string, old, new map[string]telemetryDefinition) []telemetryChange {
+	var removed, added []string
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		if _, ok := new[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(new)) {
+		if _, ok := old[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	var changes []telemetryChange
+	renamed := make(map[string]bool)
+	for _, name := range removed {
+		description := collapseSpace(old[name].Description)
+		var candidates []string
+		for _, addedName := range added {
+			if description != "" && !renamed[addedName] && collapseSpace(new[addedName].Description) == description {
+				candidates = append(candidates, addedName)
+			}
+		}
+		// Only an unambiguous match is a rename
+		if len(candidates) == 1 {
+			renamed[candidates[0]] = true
+			changes = append(changes, telemetryChange{Section: section, Name: name, Kind: telemetryRenamed, RenamedTo: candidates[0],
+				Details: definitionChanges(old[name], new[candidates[0]])})
+			continue
+		}
+		changes = append(changes, telemetryChange{Section: section, Name: name, Kind: telemetryRemoved})
+	}
+	for _, name := range added {
+		if !renamed[name] {
+			changes = append(changes, telemetryChange{Section: section, Name: name, Kind: telemetryAdded})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		newDefinition, ok := new[name]
+		if !ok {
+			continue
+		}
+		if details := definitionChanges(old[name], newDefinition); len(details) > 0 {
+			changes = append(changes, telemetryChange{Section: section, Name: name, Kind: telemetryChanged, Details: details})
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b telemetryChange) int { return strings.Compare(a.Name, b.Name) })
+	return changes
+}
+
+// diffTelemetry compares the metadata.yaml files of the old and new module. Files missing on one side are compared with an empty file.
+func diffTelemetry(component string, old, new map[string]telemetryMetadata) []componentTelemetryDiff {
+	paths := slices.Sorted(maps.Keys(old))
+	for path := range new {
+		if _, ok := old[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var diffs []componentTelemetryDiff
+	for _, path := range paths {
+		diff := componentTelemetryDiff{Component: component, Metadata: path}
+		for _, section := range telemetrySections {
+			diff.Changes = append(diff.Changes, diffTelemetrySection(section, old[path].section(section), new[path].section(section))...)
+		}
+		if len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// exampleReferences maps the scope of a component config, e.g. receivers.mysql for receivers.mysql/primary or
+// receivers.hostmetrics.scrapers.process for a scraper, to the names of its metrics, resource attributes and events
+// per section, and the config lines that refer to them.
+type exampleReferences map[string]map[string]map[string][]string
+
+// scanExampleConfigs finds the metrics, resource attributes and events configured by the components of the YAML files
+// of the examples directory, e.g. receivers.mysql.metrics or the metrics of a hostmetrics scraper. References are relative to the directory.
+func scanExampleConfigs(dir string) (exampleReferences, error) {
+	references := make(exampleReferences)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping example config %s: %v\n", path, err)
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		collectReferences(&root, filepath.ToSlash(rel), references)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan example configs in %s: %v", dir, err)
+	}
+	return references, nil
+}
+
+// collectReferences records the telemetry sections of every component of the config sections with their line.
+func collectReferences(root *yaml.Node, file string, references exampleReferences) {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return
+	}
+	for _, section := range mappingEntries(root.Content[0]) {
+		if _, ok := configSections[section[0].Value]; !ok {
+			continue
+		}
+		for _, entry := range mappingEntries(section[1]) {
+			componentType, _, _ := strings.Cut(entry[0].Value, "/")
+			collectComponentReferences(entry[1], section[0].Value+"."+componentType, file, references)
+		}
+	}
+}
+
+// collectComponentReferences records the telemetry sections of a component config, and of its scrapers, in the scope.
+func collectComponentReferences(config *yaml.Node, scope, file string, references exampleReferences) {
+	for _, entry := range mappingEntries(config) {
+		key, value := entry[0].Value, entry[1]
+		if key == "scrapers" {
+			for _, scraper := range mappingEntries(value) {
+				collectComponentReferences(scraper[1], scope+".scrapers."+scraper[0].Value, file, references)
+			}
+			continue
+		}
+		if !slices.Contains(telemetrySections, key) || value.Kind != yaml.MappingNode {
+			continue
+		}
+		if references[scope] == nil {
+			references[scope] = make(map[string]map[string][]string)
+		}
+		if references[scope][key] == nil {
+			references[scope][key] = make(map[string][]string)
+		}
+		for _, name := range mappingEntries(value) {
+			references[scope][key][name[0].Value] = append(references[scope][key][name[0].Value], fmt.Sprintf("%s:%d", file, name[0].Line))
+		}
+	}
+}
+
+// referenceScopes returns the scopes of the example configs configuring a metadata.yaml of a component module, from the type
+// of the component and, for a scraper, the type of its own metadata.yaml. The class of the component selects the config section,
+// all sections are searched when the metadata.yaml has none. A metadata.yaml without a type is configured nowhere.
+func referenceScopes(old, new map[string]telemetryMetadata, metadataPath string) []string {
+	root, ok := new["metadata.yaml"]
+	if !ok {
+		root = old["metadata.yaml"]
+	}
+	var suffix string
+	if metadataPath != "metadata.yaml" {
+		scraperType := new[metadataPath].Type
+		if scraperType == "" {
+			scraperType = old[metadataPath].Type
+		}
+		if scraperType == "" {
+			return nil
+		}
+		suffix = ".scrapers." + scraperType
+	}
+	if root.Type == "" {
+		return nil
+	}
+	var scopes []string
+	for _, section := range slices.Sorted(maps.Keys(configSections)) {
+		if root.Status.Class == "" || configSections[section] == root.Status.Class {
+			scopes = append(scopes, section+"."+root.Type+suffix)
+		}
+	}
+	return scopes
+}
+
+// telemetryChanges diffs the telemetry of every component between the old and new version of its module in the module cache,
+// and links the changes of removed, renamed and changed definitions to the example configs referring to them.
+// Components whose modules are missing in all repositories are skipped with a warning.
+func telemetryChanges(modCache string, repos []githubRepo, oldVersion, newVersion string, componentsOfInterest []string, opts analysisOptions, references exampleReferences) ([]componentTelemetryDiff, error) {
+	var diffs []componentTelemetryDiff
+	for _, component := range componentsOfInterest {
+		var oldDir, newDir string
+		var lookupErr error
+		for _, repo := range repos {
+			if oldDir, newDir, lookupErr = componentModuleDirs(modCache, repo, component, oldVersion, newVersion, opts); lookupErr == nil {
+				break
+			}
+		}
+		if lookupErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping telemetry of %s: %v\n", component, lookupErr)
+			continue
+		}
+		oldMetadata, err := readTelemetryMetadata(oldDir)
+		if err != nil {
+			return nil, err
+		}
+		newMetadata, err := readTelemetryMetadata(newDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range diffTelemetry(component, oldMetadata, newMetadata) {
+			scopes := referenceScopes(oldMetadata, newMetadata, diff.Metadata)
+			for i, change := range diff.Changes {
+				if change.Kind == telemetryAdded {
+					continue
+				}
+				for _, scope := range scopes {
+					diff.Changes[i].References = append(diff.Changes[i].References, references[scope][change.Section][change.Name]...)
+				}
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// formatTelemetryChanges formats the telemetry changes as Markdown, one block per metadata.yaml with a list per section.
+func formatTelemetryChanges(oldVersion, newVersion string, diffs []componentTelemetryDiff) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Telemetry changes (%s to %s)\n", oldVersion, newVersion))
+	if len(diffs) == 0 {
+		builder.WriteString("\nNo telemetry changes found for the analyzed components.\n")
+		return builder.String()
+	}
+	var blocks []string
+	for _, diff := range diffs {
+		var block strings.Builder
+		block.WriteString(fmt.Sprintf("#### %s", diff.Component))
+		if diff.Metadata != "metadata.yaml" {
+			block.WriteString(fmt.Sprintf(" (%s)", strings.TrimSuffix(diff.Metadata, "/metadata.yaml")))
+		}
+		block.WriteString("\n")
+		for _, section := range telemetrySections {
+			var lines []string
+			for _, change := range diff.Changes {
+				if change.Section == section {
+					lines = append(lines, formatTelemetryChange(change))
+				}
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			block.WriteString(fmt.Sprintf("- **%s**:\n", strings.Title(strings.ReplaceAll(section, "_", " "))))
+			for _, line := range lines {
+				block.WriteString("  - " + line + "\n")
+			}
+		}
+		blocks = append(blocks, block.String())
+	}
+	builder.WriteString("\n" + strings.Join(blocks, "\n---\n"))
+	return builder.String()
+}
+
+// formatTelemetryChange writes a change, e.g. "`mysql.locks` renamed to `mysql.lock.count`: unit from `1` to `{locks}`, referenced by mysql/config.yaml:60".
+func formatTelemetryChange(change telemetryChange) string {
+	var line string
+	switch change.Kind {
+	case telemetryRenamed:
+		line = fmt.Sprintf("`%s` renamed to `%s`", change.Name, change.RenamedTo)
+	case telemetryChanged:
+		line = fmt.Sprintf("`%s` changed", change.Name)
+	default:
+		line = fmt.Sprintf("`%s` %s", change.Name, change.Kind)
+	}
+	if len(change.Details) > 0 {
+		line += ": " + strings.Join(change.Details, ", ")
+	}
+	if len(change.References) > 0 {
+		line += ", referenced by " + strings.Join(change.References, ", ")
+	}
+	return line
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffTelemetrySection(t *testing.T) {
+	counter := func(description, unit string, enabled bool) telemetryDefinition {
+		return telemetryDefinition{Description: description, Unit: unit, Enabled: enabled,
+			Sum: &metricData{ValueType: "int", Monotonic: true, AggregationTemporality: "cumulative"}}
+	}
+	gauge := func(description, unit string, enabled bool) telemetryDefinition {
+		return telemetryDefinition{Description: description, Unit: unit, Enabled: enabled, Gauge: &metricData{ValueType: "double"}}
+	}
+	old := map[string]telemetryDefinition{
+		"mysql.locks":             counter("The number of MySQL locks.", "1", true),
+		"mysql.sorts":             counter("The number of MySQL sorts.", "1", true),
+		"mysql.buffer_pool.usage": counter("The number of bytes in the InnoDB buffer pool.", "By", true),
+		"mysql.uptime":            counter("The number of seconds that the server has been up.", "s", true),
+		"mysql.threads":           gauge("The state of MySQL threads.", "{threads}", true),
+		"mysql.opened_resources":  counter("The number of opened resources.", "1", true),
+	}
+	new := map[string]telemetryDefinition{
+		"mysql.lock.count":        counter("The number of MySQL locks.", "{locks}", true),
+		"mysql.sorts":             counter("The number of MySQL sorts.", "1", true),
+		"mysql.buffer_pool.usage": gauge("The number of bytes in the InnoDB buffer pool.", "By", true),
+		"mysql.uptime":            counter("The number of seconds that the server has been up.", "s", false),
+		"mysql.connection.count":  counter("The number of connections.", "1", false),
+		// Two candidates with the description of a removed metric are not a rename
+		"mysql.opened.files":  counter("The number of opened resources.", "1", true),
+		"mysql.opened.tables": counter("The number of opened resources.", "1", true),
+	}
+	got := diffTelemetrySection(sectionMetrics, old, new)
+	want := []telemetryChange{
+		{Section: sectionMetrics, Name: "mysql.buffer_pool.usage", Kind: telemetryChanged, Details: []string{"type from sum (int, monotonic, cumulative) to gauge (double)"}},
+		{Section: sectionMetrics, Name: "mysql.connection.count", Kind: telemetryAdded},
+		{Section: sectionMetrics, Name: "mysql.locks", Kind: telemetryRenamed, RenamedTo: "mysql.lock.count", Details: []string{"unit from `1` to `{locks}`"}},
+		{Section: sectionMetrics, Name: "mysql.opened.files", Kind: telemetryAdded},
+		{Section: sectionMetrics, Name: "mysql.opened.tables", Kind: telemetryAdded},
+		{Section: sectionMetrics, Name: "mysql.opened_resources", Kind: telemetryRemoved},
+		{Section: sectionMetrics, Name: "mysql.threads", Kind: telemetryRemoved},
+		{Section: sectionMetrics, Name: "mysql.uptime", Kind: telemetryChanged, Details: []string{"enabled by default from true to false"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffTelemetrySection() returned\n%+v\nbut we expected\n%+v", got, want)
+	}
+
+	attributes := diffTelemetrySection(sectionResourceAttributes,
+		map[string]telemetryDefinition{"mysql.instance.endpoint": {Type: "string", Enabled: true}},
+		map[string]telemetryDefinition{"mysql.instance.endpoint": {Type: "int", Enabled: true}})
+	wantAttributes := []telemetryChange{{Section: sectionResourceAttributes, Name: "mysql.instance.endpoint", Kind: telemetryChanged, Details: []string{"type from string to int"}}}
+	if !reflect.DeepEqual(attributes, wantAttributes) {
+		t.Errorf("diffTelemetrySection() of resource attributes returned %+v, but we expected %+v", attributes, wantAttributes)
+	}
+}
+
+func TestScanExampleConfigs(t *testing.T) {
+	examples := writeClone(t, map[string]string{
+		"mysql/config.yaml": `receivers:
+  mysql:
+    endpoint:
+    metrics:
+      mysql.locks:
+        enabled: true
+      mysql.sorts:
+        enabled: true
+`,
+		"host/config.yaml": `receivers:
+  hostmetrics:
+    scrapers:
+      process:
+        resource_attributes:
+          process.command_line:
+            enabled: false
+        metrics:
+          process.cpu.time:
+            enabled: true
+`,
+		"mysql/README.md":     "metrics:\n  mysql.locks:\n",
+		"broken/config.yaml":  "receivers: [\n",
+		"nop/config.yaml":     "receivers:\n  nop:\n",
+		"events/config.yaml":  "receivers:\n  mysql/events:\n    events:\n      db.server.query_sample:\n        enabled: true\n",
+		"metrics/config.yaml": "processors:\n  filter:\n    metrics: [not, a, mapping]\n",
+	})
+	references, err := scanExampleConfigs(examples)
+	if err != nil {
+		t.Fatalf("scanExampleConfigs failed: %v", err)
+	}
+	want := exampleReferences{
+		"receivers.mysql": {
+			sectionMetrics: {"mysql.locks": {"mysql/config.yaml:5"}, "mysql.sorts": {"mysql/config.yaml:7"}},
+			sectionEvents:  {"db.server.query_sample": {"events/config.yaml:4"}},
+		},
+		"receivers.hostmetrics.scrapers.process": {
+			sectionMetrics:            {"process.cpu.time": {"host/config.yaml:9"}},
+			sectionResourceAttributes: {"process.command_line": {"host/config.yaml:6"}},
+		},
+	}
+	if !reflect.DeepEqual(references, want) {
+		t.Errorf("scanExampleConfigs() returned %v, but we expected %v", references, want)
+	}
+}
+
+func TestTelemetryChangesScopedReferences(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		contribModules + "receiver/hostmetricsreceiver@v0.121.0/metadata.yaml":                                 "type: hostmetrics\nstatus:\n  class: receiver\n",
+		contribModules + "receiver/hostmetricsreceiver@v0.122.0/metadata.yaml":                                 "type: hostmetrics\nstatus:\n  class: receiver\n",
+		contribModules + "receiver/hostmetricsreceiver@v0.121.0/internal/scraper/processscraper/metadata.yaml": "type: process\nmetrics:\n  process.cpu.time:\n    unit: s\n",
+		contribModules + "receiver/hostmetricsreceiver@v0.122.0/internal/scraper/processscraper/metadata.yaml": "type: process\n",
+	})
+	// Only the process scraper of the hostmetrics receiver configures the removed metric
+	references := exampleReferences{
+		"receivers.hostmetrics.scrapers.process":  {sectionMetrics: {"process.cpu.time": {"host/config.yaml:9"}}},
+		"receivers.hostmetrics":                   {sectionMetrics: {"process.cpu.time": {"host/config.yaml:4"}}},
+		"processors.hostmetrics.scrapers.process": {sectionMetrics: {"process.cpu.time": {"processors/config.yaml:6"}}},
+		"receivers.kubeletstats":                  {sectionMetrics: {"process.cpu.time": {"kubelet/config.yaml:5"}}},
+	}
+	repo := mustParseRepo(t, "opentelemetry-collector-contrib")
+	diffs, err := telemetryChanges(modCache, []githubRepo{repo}, "v0.121.0", "v0.122.0", []string{"hostmetricsreceiver"}, analysisOptions{}, references)
+	if err != nil {
+		t.Fatalf("telemetryChanges failed: %v", err)
+	}
+	want := []componentTelemetryDiff{{
+		Component: "hostmetricsreceiver",
+		Metadata:  "internal/scraper/processscraper/metadata.yaml",
+		Changes:   []telemetryChange{{Section: sectionMetrics, Name: "process.cpu.time", Kind: telemetryRemoved, References: []string{"host/config.yaml:9"}}},
+	}}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("telemetryChanges() returned\n%+v\nbut we expected\n%+v", diffs, want)
+	}
+}
+
+func TestRunTelemetry(t *testing.T) {
+	modCache := writeModCache(t, map[string]string{
+		contribModules + "receiver/mysqlreceiver@v0.121.0/metadata.yaml": `type: mysql
+resource_attributes:
+  mysql.instance.endpoint:
+    description: Endpoint of the MySQL instance.
+    enabled: true
+    type: string
+metrics:
+  mysql.locks:
+    enabled: true
+    description: The number of MySQL locks.
+    unit: "1"
+    sum:
+      value_type: int
+      monotonic: true
+      aggregation_temporality: cumulative
+  mysql.sorts:
+    enabled: true
+    description: The number of MySQL sorts.
+    unit: "1"
+    sum:
+      value_type: int
+      monotonic: true
+      aggregation_temporality: cumulative
+`,
+		contribModules + "receiver/mysqlreceiver@v0.122.0/metadata.yaml": `type: mysql
+resource_attributes:
+  mysql.instance.endpoint:
+    description: Endpoint of the MySQL instance.
+    enabled: true
+    type: string
+metrics:
+  mysql.lock.count:
+    enabled: true
+    description: The number of MySQL locks.
+    unit: "{locks}"
+    sum:
+      value_type: int
+      monotonic: true
+      aggregation_temporality: cumulative
+  mysql.sorts:
+    enabled: false
+    description: The number of MySQL sorts.
+    unit: "1"
+    sum:
+      value_type: int
+      monotonic: true
+      aggregation_temporality: cumulative
+`,
+		contribModules + "receiver/hostmetricsreceiver@v0.121.0/internal/scraper/processscraper/metadata.yaml": "resource_attributes:\n  process.command_line:\n    type: string\n",
+		contribModules + "receiver/hostmetricsreceiver@v0.122.0/internal/scraper/processscraper/metadata.yaml": "resource_attributes:\n  process.command_line:\n    type: string\n",
+	})
+	examples := writeClone(t, map[string]string{
+		"mysql/config.yaml": "receivers:\n  mysql:\n    metrics:\n      mysql.locks:\n        enabled: true\n      mysql.sorts:\n        enabled: true\n",
+	})
+	args := []string{"telemetry", "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0",
+		"--components", "mysqlreceiver,hostmetricsreceiver", "--modcache", modCache, "--examples", examples}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	want := "# Telemetry changes (v0.121.0 to v0.122.0)\n\n#### mysqlreceiver\n- **Metrics**:\n" +
+		"  - `mysql.locks` renamed to `mysql.lock.count`: unit from `1` to `{locks}`, referenced by mysql/config.yaml:4\n" +
+		"  - `mysql.sorts` changed: enabled by default from true to false, referenced by mysql/config.yaml:6\n"
+	if stdout.String() != want {
+		t.Errorf("run() printed %q, but we expected %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if code := run(append(args, "--format", "json"), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"renamed_to": "mysql.lock.count"`) || !strings.Contains(stdout.String(), `"metadata": "metadata.yaml"`) {
+		t.Errorf("run() printed unexpected JSON:\n%s", stdout.String())
+	}
+
+	stderr.Reset()
+	if code := run([]string{"telemetry", "--repo", "opentelemetry-collector-contrib", "--components", "mysqlreceiver"}, &stdout, &stderr); code != 1 ||
+		!strings.Contains(stderr.String(), "Error: old and new module versions are required") {
+		t.Errorf("run() without versions = %d, stderr:\n%s", code, stderr.String())
+	}
+}
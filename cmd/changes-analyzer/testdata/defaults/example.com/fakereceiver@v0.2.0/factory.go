@@ -0,0 +1,21 @@
+package fakereceiver
+
+import "time"
+
+type Config struct {
+	Endpoint string        `mapstructure:"endpoint"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	TLS      TLSConfig     `mapstructure:"tls"`
+}
+
+type TLSConfig struct {
+	Insecure bool `mapstructure:"insecure"`
+}
+
+type Factory struct{}
+
+func NewFactory() Factory { return Factory{} }
+
+func (Factory) CreateDefaultConfig() any {
+	return &Config{Endpoint: "localhost:4317", Timeout: 10 * time.Second, TLS: TLSConfig{Insecure: true}}
+}
@@ -0,0 +1,5 @@
+module example.com/fakereceiver
+
+go 1.22
+
+require go.opentelemetry.io/collector/confmap v1.28.0
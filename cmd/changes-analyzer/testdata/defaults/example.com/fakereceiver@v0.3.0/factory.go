@@ -0,0 +1,10 @@
+package fakereceiver
+
+type Factory struct{}
+
+func NewFactory() Factory { return Factory{} }
+
+// CreateDefaultConfig returns no config, which cannot be marshaled.
+func (Factory) CreateDefaultConfig() any {
+	return nil
+}
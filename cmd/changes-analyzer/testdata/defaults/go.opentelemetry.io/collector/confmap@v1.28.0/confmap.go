@@ -0,0 +1,45 @@
+// Package confmap is a minimal stand-in of the collector confmap, marshaling structs by their mapstructure tags.
+package confmap
+
+import (
+	"errors"
+	"reflect"
+)
+
+type Conf struct {
+	values map[string]any
+}
+
+func New() *Conf {
+	return &Conf{values: map[string]any{}}
+}
+
+func (c *Conf) Marshal(config any) error {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return errors.New("cannot marshal a config that is not a struct")
+	}
+	c.values = marshalStruct(value)
+	return nil
+}
+
+func (c *Conf) ToStringMap() map[string]any {
+	return c.values
+}
+
+func marshalStruct(value reflect.Value) map[string]any {
+	values := map[string]any{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		key := value.Type().Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			values[key] = marshalStruct(field)
+			continue
+		}
+		values[key] = field.Interface()
+	}
+	return values
+}
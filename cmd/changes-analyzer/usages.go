@@ -0,0 +1,369 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ownModulePrefix selects the requirements of a go.mod file that are our own modules, whose code is scanned.
+const ownModulePrefix = "github.com/solarwinds/"
+
+// goFileUsages holds the imports and identifier usages of a Go file.
+type goFileUsages struct {
+	File string
+	// Imports maps the import paths to the line of their import.
+	Imports map[string]int
+	// Qualified lists the usages of identifiers of imported packages, e.g. pcommon.NewMap.
+	Qualified []qualifiedUsage
+	// Members maps the names of selected fields and methods to the lines they are used on, e.g. PutStr of attrs.PutStr.
+	// Their types are not known, they are only matched in files importing the package of the type.
+	Members map[string][]int
+}
+
+// qualifiedUsage is the usage of an identifier of an imported package.
+type qualifiedUsage struct {
+	Package string
+	Name    string
+	Line    int
+}
+
+// scanGoSources scans the Go files of our code. A source is a directory, or a go.mod file whose requirements of our own
+// modules are scanned in the module cache at their pinned version. Sources that cannot be read are skipped with a warning.
+func scanGoSources(modCache string, sources []string) []goFileUsages {
+	var files []goFileUsages
+	for _, source := range sources {
+		if filepath.Base(source) != "go.mod" {
+			scanned, err := scanGoDir(source, source)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping sources of %s: %v\n", source, err)
+			}
+			files = append(files, scanned...)
+			continue
+		}
+		goMod, err := readGoMod(source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping sources of %s: %v\n", source, err)
+			continue
+		}
+		for _, require := range goMod.Requires {
+			if require.Indirect || !strings.HasPrefix(require.Path, ownModulePrefix) {
+				continue
+			}
+			dir := moduleCacheDir(modCache, require.Path, require.Version)
+			scanned, err := scanGoDir(dir, require.Path+"@"+require.Version)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping sources of %s@%s: %v\n", require.Path, require.Version, err)
+			}
+			files = append(files, scanned...)
+		}
+	}
+	return files
+}
+
+// scanGoDir scans the Go files of a directory tree, the files are named relative to it with the label as prefix.
+// Test files, testdata and vendored code are skipped, they are not part of what we build.
+func scanGoDir(dir, label string) ([]goFileUsages, error) {
+	var files []goFileUsages
+	fset := gotoken.NewFileSet()
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if file != dir && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", file, err)
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		files = append(files, fileUsages(fset, parsed, path.Join(filepath.ToSlash(label), filepath.ToSlash(rel))))
+		return nil
+	})
+	if err != nil {
+		return files, fmt.Errorf("failed to scan %s: %v", dir, err)
+	}
+	return files, nil
+}
+
+// fileUsages collects the imports and identifier usages of a parsed file. Identifiers are matched to imports by the
+// local name of the import, which is its alias or the last element of its path.
+func fileUsages(fset *gotoken.FileSet, file *ast.File, name string) goFileUsages {
+	usages := goFileUsages{File: name, Imports: make(map[string]int), Members: make(map[string][]int)}
+	localNames := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		usages.Imports[importPath] = fset.Position(spec.Pos()).Line
+		localName := packageName(importPath)
+		if spec.Name != nil {
+			localName = spec.Name.Name
+		}
+		if localName != "_" && localName != "." {
+			localNames[localName] = importPath
+		}
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		line := fset.Position(selector.Sel.Pos()).Line
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if importPath, ok := localNames[ident.Name]; ok {
+				usages.Qualified = append(usages.Qualified, qualifiedUsage{Package: importPath, Name: selector.Sel.Name, Line: line})
+				return true
+			}
+		}
+		usages.Members[selector.Sel.Name] = append(usages.Members[selector.Sel.Name], line)
+		return true
+	})
+	return usages
+}
+
+// packageName guesses the name of a package from its import path, e.g. pcommon of go.opentelemetry.io/collector/pdata/pcommon.
+func packageName(importPath string) string {
+	return strings.ReplaceAll(path.Base(majorSuffix.ReplaceAllString(importPath, "")), "-", "")
+}
+
+// apiSymbol is a package path or Go identifier mentioned by a changelog entry, e.g. pcommon.Map.PutEmptyBytes.
+type apiSymbol struct {
+	Text string
+	// ImportPath is set for package paths, the other fields for identifiers.
+	ImportPath string
+	// Package is the package name qualifying the identifier, empty when the entry's component scopes it.
+	Package string
+	Name    string
+	// Member is the field or method of the Name type.
+	Member string
+}
+
+// entryComponentPattern matches the component an entry starts with, e.g. `pdata`: or pkg/ottl:.
+var entryComponentPattern = regexp.MustCompile("^`?([A-Za-z0-9_./-]+)`?:")
+
+// goIdentifierPattern matches Go identifiers and selectors, e.g. NewMap or pcommon.Map.PutStr.
+var goIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*){0,2}$`)
+
+// entrySymbols extracts the package paths and exported identifiers an entry mentions, from its code spans and its
+// code-like words. Calls and pointers are reduced to the identifier, e.g. *pcommon.Map and NewMap() are accepted.
+func entrySymbols(text string) []apiSymbol {
+	var symbols []apiSymbol
+	seen := make(map[string]bool)
+	for _, t := range tokenizeDescription(text) {
+		if t.Kind != tokenCode && (t.Kind != tokenWord || !isCodeLike(t.Text)) {
+			continue
+		}
+		word := strings.Trim(t.Text, "`")
+		word, _, _ = strings.Cut(word, "(")
+		word = strings.TrimLeft(strings.TrimSpace(word), "*&")
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		if modulePathPattern.MatchString(word) {
+			symbols = append(symbols, apiSymbol{Text: word, ImportPath: word})
+			continue
+		}
+		if !goIdentifierPattern.MatchString(word) {
+			continue
+		}
+		parts := strings.Split(word, ".")
+		symbol := apiSymbol{Text: word}
+		if !ast.IsExported(parts[0]) {
+			if len(parts) == 1 {
+				continue
+			}
+			symbol.Package, parts = parts[0], parts[1:]
+		}
+		if len(parts) > 2 || !ast.IsExported(parts[0]) {
+			continue
+		}
+		symbol.Name = parts[0]
+		if len(parts) == 2 {
+			symbol.Member = parts[1]
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// usageReference is a line of our code using a package path or identifier mentioned by a changelog entry.
+type usageReference struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Symbol string `json:"symbol"`
+}
+
+// apiUsage is an API changelog entry whose package paths or identifiers are used by our code.
+type apiUsage struct {
+	Version  string `json:"version"`
+	Category string `json:"category"`
+	Text     string `json:"text"`
+	// AlsoIn lists the later versions repeating the entry, e.g. a patch release.
+	AlsoIn     []string         `json:"also_in,omitempty"`
+	References []usageReference `json:"references"`
+}
+
+// inScope reports whether an import path belongs to the component of an entry, e.g. pdata scopes
+// go.opentelemetry.io/collector/pdata/pcommon and pkg/ottl scopes the ottl packages of contrib.
+func inScope(importPath, component string) bool {
+	return component != "" && strings.Contains(importPath+"/", "/"+strings.Trim(component, "/")+"/")
+}
+
+// symbolReferences returns the lines of a file using a symbol of an entry of the component.
+func symbolReferences(file goFileUsages, symbol apiSymbol, component string) []usageReference {
+	var refs []usageReference
+	ref := func(line int) {
+		refs = append(refs, usageReference{File: file.File, Line: line, Symbol: symbol.Text})
+	}
+	if symbol.ImportPath != "" {
+		for importPath, line := range file.Imports {
+			if importPath == symbol.ImportPath || strings.HasPrefix(importPath, symbol.ImportPath+"/") {
+				ref(line)
+			}
+		}
+		return refs
+	}
+	// The package of the identifier is given by its qualifier, or by the component of the entry
+	matches := func(importPath string) bool {
+		if symbol.Package != "" {
+			return packageName(importPath) == symbol.Package
+		}
+		return inScope(importPath, component)
+	}
+	imported := false
+	for importPath := range file.Imports {
+		imported = imported || matches(importPath)
+	}
+	if !imported {
+		return nil
+	}
+	if symbol.Member != "" {
+		for _, line := range file.Members[symbol.Member] {
+			ref(line)
+		}
+		return refs
+	}
+	for _, usage := range file.Qualified {
+		if usage.Name == symbol.Name && matches(usage.Package) {
+			ref(usage.Line)
+		}
+	}
+	return refs
+}
+
+// apiUsages flags the API changelog entries of the release notes whose package paths or identifiers our code uses.
+// Only the analyzed categories are correlated, entries of the end user changelog are not about the Go API.
+// Entries repeated across releases are correlated once, like in the report of the component changes.
+func apiUsages(releaseNotes map[string]map[string][]changeEntry, files []goFileUsages, opts analysisOptions) []apiUsage {
+	byCategory := make(map[string][]changeEntry)
+	for ver, sections := range releaseNotes {
+		for category, entries := range sections {
+			if !slices.Contains(allCategories, category) || !opts.includesCategory(category) {
+				continue
+			}
+			for _, entry := range entries {
+				if entry.Audience == audienceAPI {
+					entry.Version = ver
+					byCategory[category] = append(byCategory[category], entry)
+				}
+			}
+		}
+	}
+	var usages []apiUsage
+	for category, entries := range byCategory {
+		sortEntries(entries)
+		for _, entry := range dedupeEntries(entries) {
+			component := ""
+			if match := entryComponentPattern.FindStringSubmatch(entry.Text); match != nil {
+				component = match[1]
+			}
+			var refs []usageReference
+			for _, symbol := range entrySymbols(entry.Text) {
+				for _, file := range files {
+					refs = append(refs, symbolReferences(file, symbol, component)...)
+				}
+			}
+			if len(refs) == 0 {
+				continue
+			}
+			slices.SortFunc(refs, func(a, b usageReference) int {
+				if a.File != b.File {
+					return strings.Compare(a.File, b.File)
+				}
+				if a.Line != b.Line {
+					return a.Line - b.Line
+				}
+				return strings.Compare(a.Symbol, b.Symbol)
+			})
+			usages = append(usages, apiUsage{Version: entry.Version, Category: category, Text: entry.Text, AlsoIn: entry.AlsoIn, References: slices.Compact(refs)})
+		}
+	}
+	slices.SortFunc(usages, func(a, b apiUsage) int {
+		if c := compareVersions(a.Version, b.Version); c != 0 {
+			return c
+		}
+		if a.Category != b.Category {
+			return slices.Index(allCategories, a.Category) - slices.Index(allCategories, b.Category)
+		}
+		return strings.Compare(a.Text, b.Text)
+	})
+	return usages
+}
+
+// formatAPIUsages formats the API changelog entries used by our code as a dedicated section of the report.
+// Entries are written like in the report of the component changes, with their references linked.
+func formatAPIUsages(repo githubRepo, oldVersion, newVersion string, usages []apiUsage) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### API changes used by our code (%s to %s)\n\n", oldVersion, newVersion))
+	for _, usage := range usages {
+		notes := usage.Category
+		if len(usage.AlsoIn) > 0 {
+			notes += fmt.Sprintf(", also in %s", strings.Join(usage.AlsoIn, ", "))
+		}
+		// Nested lists and paragraphs of the entry are indented under its list item
+		text := indentContinuation(formatEntryText(repo, usage.Text), "  ")
+		builder.WriteString(fmt.Sprintf("- %s (%s): %s\n", usage.Version, notes, text))
+		for _, ref := range usage.References {
+			builder.WriteString(fmt.Sprintf("  - `%s:%d`: `%s`\n", ref.File, ref.Line, ref.Symbol))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEntrySymbols(t *testing.T) {
+	tests := []struct {
+		text string
+		want []apiSymbol
+	}{
+		{
+			text: "`pdata`: Remove deprecated `pcommon.Map.PutEmptyBytes` and `NewProfiles()` (#1234)",
+			want: []apiSymbol{
+				{Text: "pcommon.Map.PutEmptyBytes", Package: "pcommon", Name: "Map", Member: "PutEmptyBytes"},
+				{Text: "NewProfiles", Name: "NewProfiles"},
+			},
+		},
+		{
+			text: "`component`: Move `*component.TelemetrySettings` to go.opentelemetry.io/collector/component/componenttest",
+			want: []apiSymbol{
+				{Text: "component.TelemetrySettings", Package: "component", Name: "TelemetrySettings"},
+				{Text: "go.opentelemetry.io/collector/component/componenttest", ImportPath: "go.opentelemetry.io/collector/component/componenttest"},
+			},
+		},
+		{
+			text: "`pkg/ottl`: Rename `ottl.pathGetter` e.g. for `enabled` paths",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		if got := entrySymbols(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("entrySymbols(%q) returned %+v, but we expected %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAPIUsages(t *testing.T) {
+	dir := writeClone(t, map[string]string{
+		"processor/attributes.go": `package processor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+func update(attrs pcommon.Map) {
+	attrs.PutEmptyBytes("key")
+	_ = pcommon.NewMap()
+	_ = conventions.AttributeServiceName
+}
+`,
+		"processor/attributes_test.go": "package processor\n\nimport \"go.opentelemetry.io/collector/pdata/pcommon\"\n\nvar _ = pcommon.NewMap\n",
+		"exporter/exporter.go": `package exporter
+
+import "go.opentelemetry.io/collector/pdata/plog"
+
+func export(logs plog.Logs) {
+	logs.PutEmptyBytes()
+	_ = plog.NewProfiles
+}
+`,
+	})
+	releaseNotes := map[string]map[string][]changeEntry{
+		"0.122.0": {
+			breakingChanges: {
+				{Text: "`pdata`: Remove `pcommon.Map.PutEmptyBytes` (#1)", Audience: audienceAPI},
+				{Text: "`pdata`: Remove `pcommon.Map.PutEmptyBytes` from the docs (#2)", Audience: audienceUser},
+				{Text: "`pdata`: Remove `NewProfiles` (#3)", Audience: audienceAPI},
+				{Text: "`semconv`: Remove `v1.9.0` of go.opentelemetry.io/collector/semconv (#4)", Audience: audienceAPI},
+			},
+			deprecations: {
+				{Text: "`pdata`: Deprecate `pcommon.NewMap` (#5)", Audience: audienceAPI},
+				{Text: "`consumer`: Deprecate `consumer.Capabilities` (#6)", Audience: audienceAPI},
+			},
+		},
+		// The patch release repeats an entry of the minor release, it is flagged once
+		"0.122.1": {
+			breakingChanges: {{Text: "`pdata`: Remove `pcommon.Map.PutEmptyBytes` (#1)", Audience: audienceAPI}},
+		},
+		"0.121.0": {
+			enhancements:   {{Text: "`pdata`: Add `pcommon.Map.PutEmptyBytes` (#7)", Audience: audienceAPI}},
+			skippedSection: {{Text: "`pdata`: Fix `pcommon.NewMap` (#8)", Audience: audienceAPI}},
+		},
+	}
+	attributes := filepath.ToSlash(dir) + "/processor/attributes.go"
+	exporter := filepath.ToSlash(dir) + "/exporter/exporter.go"
+	want := []apiUsage{
+		{Version: "0.121.0", Category: enhancements, Text: "`pdata`: Add `pcommon.Map.PutEmptyBytes` (#7)", References: []usageReference{{attributes, 9, "pcommon.Map.PutEmptyBytes"}}},
+		{Version: "0.122.0", Category: breakingChanges, Text: "`pdata`: Remove `NewProfiles` (#3)", References: []usageReference{{exporter, 7, "NewProfiles"}}},
+		{Version: "0.122.0", Category: breakingChanges, Text: "`pdata`: Remove `pcommon.Map.PutEmptyBytes` (#1)", AlsoIn: []string{"0.122.1"}, References: []usageReference{{attributes, 9, "pcommon.Map.PutEmptyBytes"}}},
+		{Version: "0.122.0", Category: breakingChanges, Text: "`semconv`: Remove `v1.9.0` of go.opentelemetry.io/collector/semconv (#4)", References: []usageReference{{attributes, 5, "go.opentelemetry.io/collector/semconv"}}},
+		{Version: "0.122.0", Category: deprecations, Text: "`pdata`: Deprecate `pcommon.NewMap` (#5)", References: []usageReference{{attributes, 10, "pcommon.NewMap"}}},
+	}
+	got := apiUsages(releaseNotes, scanGoSources("", []string{dir}), analysisOptions{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("apiUsages() returned\n%+v\nbut we expected\n%+v", got, want)
+	}
+
+	// Entries are rendered like in the report of the component changes, the sub-text stays under its list item
+	usages := []apiUsage{
+		want[2],
+		{Version: "0.122.0", Category: deprecations, Text: "`pdata`: Deprecate `pcommon.NewMap`, thanks @jdoe (#5)\n- Use `pcommon.NewValueMap` <instead>", References: want[4].References},
+	}
+	wantMarkdown := "### API changes used by our code (v0.121.0 to v0.122.1)\n\n" +
+		"- 0.122.0 (breaking_changes, also in 0.122.1): `pdata`: Remove `pcommon.Map.PutEmptyBytes` ([#1](https://github.com/open-telemetry/opentelemetry-collector/pull/1))\n" +
+		"  - `" + attributes + ":9`: `pcommon.Map.PutEmptyBytes`\n" +
+		"- 0.122.0 (deprecations): `pdata`: Deprecate `pcommon.NewMap`, thanks `@jdoe` ([#5](https://github.com/open-telemetry/opentelemetry-collector/pull/5))\n" +
+		"  - Use `pcommon.NewValueMap` &lt;instead>\n" +
+		"  - `" + attributes + ":10`: `pcommon.NewMap`\n"
+	if got := formatAPIUsages(mustParseRepo(t, "opentelemetry-collector"), "v0.121.0", "v0.122.1", usages); got != wantMarkdown {
+		t.Errorf("formatAPIUsages() returned %q, but we expected %q", got, wantMarkdown)
+	}
+}
+
+func TestRunUsages(t *testing.T) {
+	fixture := filepath.Join("testdata", "synthetic-contrib-v0.121.0-v0.122.0-api.json")
+	modCache := writeModCache(t, map[string]string{
+		"github.com/solarwinds/solarwinds-otel-collector-contrib/processor/k8seventgenerationprocessor@v0.122.0/processor.go": `package k8seventgenerationprocessor
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+var condition = ottl.NewBoolExprForSpanEvent
+`,
+	})
+	dir := writeClone(t, map[string]string{
+		"go.mod": `module github.com/solarwinds/solarwinds-otel-collector-releases/verified
+
+require (
+	github.com/solarwinds/solarwinds-otel-collector-contrib/processor/k8seventgenerationprocessor v0.122.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl v0.122.0
+)
+`,
+	})
+	args := []string{"usages", "--replay", fixture, "--repo", "opentelemetry-collector-contrib", "--old", "v0.121.0", "--new", "v0.122.0",
+		"--sources", filepath.Join(dir, "go.mod"), "--modcache", modCache}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr:\n%s", code, stderr.String())
+	}
+	want := "### API changes used by our code (v0.121.0 to v0.122.0)\n\n" +
+		"- 0.122.0 (breaking_changes): `pkg/ottl`: Remove `ottl.NewBoolExprForSpanEvent` in favour of generic functions"
+	wantReference := "  - `github.com/solarwinds/solarwinds-otel-collector-contrib/processor/k8seventgenerationprocessor@v0.122.0/processor.go:5`: `ottl.NewBoolExprForSpanEvent`\n"
+	if !strings.Contains(stdout.String(), want) || !strings.Contains(stdout.String(), wantReference) {
+		t.Errorf("run() printed %q, but we expected %q and %q", stdout.String(), want, wantReference)
+	}
+
+	stdout.Reset()
+	if code := run(append(args, "--format", "json"), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), `"line": 5`) {
+		t.Errorf("run() = %d, printed unexpected JSON:\n%s", code, stdout.String())
+	}
+
+	stderr.Reset()
+	if code := run(args[:len(args)-4], &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "Error: sources are required") {
+		t.Errorf("run() without sources = %d, stderr:\n%s", code, stderr.String())
+	}
+}